@@ -9,8 +9,12 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"sync"
 )
 
+// TenantProxyPath is the path under which a system proxies requests to its tenants
+const TenantProxyPath = "tenant"
+
 // Options represents an option from the Verge.IO api.
 type Options struct {
 	Limit  string
@@ -44,6 +48,48 @@ type Client struct {
 	Host       string
 	Insecure   bool
 	HTTPClient *http.Client
+	// Tenant is the key or name of the tenant operations target by default, empty for the system itself
+	Tenant string
+
+	tenantsLock sync.Mutex
+	tenants     map[string]*Client
+}
+
+// TenantClient returns a client for tenant, given by key or name, that sends its
+// requests through the tenant proxy of this system. The proxy does not exchange
+// credentials, the username and password of c are sent as they are and have to
+// be valid for a user inside the tenant. Clients are cached per tenant so the
+// lookup only happens once.
+func (c *Client) TenantClient(tenant string) (*Client, error) {
+	c.tenantsLock.Lock()
+	defer c.tenantsLock.Unlock()
+	if client, ok := c.tenants[tenant]; ok {
+		return client, nil
+	}
+
+	key, err := getTenantKey(c, tenant)
+	if err != nil {
+		return nil, err
+	}
+	running, err := getTenantRunning(c, key)
+	if err != nil {
+		return nil, err
+	}
+	if !running {
+		return nil, fmt.Errorf("tenant %s is not running, it must be powered on to be managed through the tenant proxy", tenant)
+	}
+
+	client := &Client{
+		Username: c.Username,
+		Password: c.Password,
+		Host:     fmt.Sprintf("%s/%s/%d", c.Host, TenantProxyPath, key),
+		Insecure: c.Insecure,
+	}
+	if c.tenants == nil {
+		c.tenants = make(map[string]*Client)
+	}
+	c.tenants[tenant] = client
+	return client, nil
 }
 
 // Do Will just call the Verge.IO api but also add auth to it and some extra headers
@@ -0,0 +1,92 @@
+package vergeio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAuthSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	opts := Options{
+		Fields: "$key,name,driver,settings",
+		Filter: fmt.Sprintf("name eq '%s'", d.Get("name").(string)),
+	}
+
+	resp, err := c.Get(AuthSourceEndpoint, &opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resp != nil {
+		if resp.StatusCode == 200 {
+			var authSources []AuthSource
+			body, readerr := ioutil.ReadAll(resp.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &authSources)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			if len(authSources) != 1 {
+				return diag.Errorf("Expected 1 auth source named %q, found %d", d.Get("name").(string), len(authSources))
+			}
+			authSource := authSources[0]
+
+			d.Set("driver", authSource.Driver)
+			d.Set("server_url", authSource.Settings.ServerURL)
+			d.Set("client_id", authSource.Settings.ClientID)
+			d.Set("scopes", strings.Fields(authSource.Settings.Scope))
+			d.Set("auto_create_users", authSource.Settings.AutoCreateUsers)
+			d.SetId(strconv.Itoa(authSource.Key))
+		}
+	} else {
+		return diag.Errorf("Error retrieving auth sources")
+	}
+	return diags
+}
+
+func dataSourceAuthSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAuthSourceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `Name of the auth source to look up`,
+			},
+			"driver": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"server_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"auto_create_users": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package vergeio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudSnapshotsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	opts := Options{
+		Fields: "$key,name,description,created,expires,immutable,private",
+		Sort:   "-created",
+	}
+	if fn := d.Get("filter_name"); fn != nil && fn != "" {
+		opts.Filter = fmt.Sprintf("name eq '%s'", fn.(string))
+	}
+
+	resp, err := c.Get(CloudSnapshotEndpoint, &opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resp != nil {
+		if resp.StatusCode == 200 {
+			var snapshotData []CloudSnapshot
+			body, readerr := ioutil.ReadAll(resp.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &snapshotData)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			var snapshots []map[string]interface{}
+
+			for _, snapshot := range snapshotData {
+
+				n := map[string]interface{}{
+					"id":          snapshot.Key,
+					"name":        snapshot.Name,
+					"description": snapshot.Description,
+					"created":     time.Unix(snapshot.Created, 0).UTC().Format(time.RFC3339),
+					"expiration":  cloudSnapshotExpiration(snapshot),
+					"immutable":   snapshot.Immutable,
+					"private":     snapshot.Private,
+				}
+				snapshots = append(snapshots, n)
+			}
+			err = d.Set("snapshots", snapshots)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(time.Now().UTC().Format(time.RFC3339Nano))
+		}
+	} else {
+		return diag.Errorf("Error retrieving cloud snapshots")
+	}
+	return diags
+}
+
+func dataSourceCloudSnapshots() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudSnapshotsRead,
+		Schema: map[string]*schema.Schema{
+			"filter_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `If specified, results will be filtered to name`,
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: `Cloud snapshots, newest first`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expiration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"immutable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"private": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package vergeio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Drives structure to store drive specific data in
+type Drives struct {
+	ID            int    `json:"$key,omitempty"`
+	Machine       int    `json:"machine,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Interface     string `json:"interface,omitempty"`
+	Media         string `json:"media,omitempty"`
+	MediaSource   int    `json:"media_source,omitempty"`
+	DiskSize      int    `json:"disksize,omitempty"`
+	PreferredTier string `json:"preferred_tier,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	ReadOnly      bool   `json:"readonly"`
+}
+
+func dataSourceDrivesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	opts := Options{Fields: "$key,machine,name,description,interface,media,media_source,disksize,preferred_tier,enabled,readonly"}
+
+	// Build filter
+	filters := []string{fmt.Sprintf("machine eq %d", d.Get("machine").(int))}
+	if fn := d.Get("filter_name"); fn != nil && fn != "" {
+		filters = append(filters, fmt.Sprintf("name eq '%s'", fn.(string)))
+	}
+	opts.Filter = strings.Join(filters, " and ")
+
+	resp, err := c.Get(DriveEndpoint, &opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resp != nil {
+		if resp.StatusCode == 200 {
+			var drivesData []Drives
+			body, readerr := ioutil.ReadAll(resp.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &drivesData)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			var drives []map[string]interface{}
+
+			for _, drive := range drivesData {
+
+				n := map[string]interface{}{
+					"id":             drive.ID,
+					"machine":        drive.Machine,
+					"name":           drive.Name,
+					"description":    drive.Description,
+					"interface":      drive.Interface,
+					"media":          drive.Media,
+					"media_source":   drive.MediaSource,
+					"disksize":       drive.DiskSize / (1024 * 1024 * 1024), // Convert bytes to GB
+					"preferred_tier": drive.PreferredTier,
+					"enabled":        drive.Enabled,
+					"readonly":       drive.ReadOnly,
+				}
+				drives = append(drives, n)
+			}
+			err = d.Set("drives", drives)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(time.Now().UTC().Format(time.RFC3339Nano))
+		}
+	} else {
+		return diag.Errorf("Error retrieving drives")
+	}
+	return diags
+}
+
+func dataSourceDrives() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDrivesRead,
+		Schema: map[string]*schema.Schema{
+			"machine": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: `Machine key of the virtual machine the drives are attached to`,
+			},
+			"filter_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `If specified, results will be filtered to name`,
+			},
+			"drives": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"machine": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interface": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"media": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"media_source": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"disksize": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"preferred_tier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"readonly": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
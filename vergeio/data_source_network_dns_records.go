@@ -0,0 +1,162 @@
+package vergeio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NetworkDNSRecords structure to store dns record specific data in
+type NetworkDNSRecords struct {
+	ID           int    `json:"$key,omitempty"`
+	Zone         int    `json:"zone,omitempty"`
+	Host         string `json:"host,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Value        string `json:"value,omitempty"`
+	TTL          string `json:"ttl,omitempty"`
+	MXPreference int    `json:"mx_preference,omitempty"`
+	Weight       int    `json:"weight,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+func dataSourceNetworkDNSRecordsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	opts := Options{Fields: "$key,zone,host,type,value,ttl,mx_preference,weight,port,description"}
+
+	// Build filter
+	filters := []string{fmt.Sprintf("zone eq %d", d.Get("zone").(int))}
+	if fn := d.Get("filter_host"); fn != nil && fn != "" {
+		filters = append(filters, fmt.Sprintf("host eq '%s'", fn.(string)))
+	}
+	if ft := d.Get("filter_type"); ft != nil && ft != "" {
+		filters = append(filters, fmt.Sprintf("type eq '%s'", ft.(string)))
+	}
+	opts.Filter = strings.Join(filters, " and ")
+
+	resp, err := c.Get(NetworkDNSRecordEndpoint, &opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resp != nil {
+		if resp.StatusCode == 200 {
+			var recordsData []NetworkDNSRecords
+			body, readerr := ioutil.ReadAll(resp.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &recordsData)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			var records []map[string]interface{}
+
+			for _, record := range recordsData {
+
+				n := map[string]interface{}{
+					"id":            record.ID,
+					"zone":          record.Zone,
+					"host":          record.Host,
+					"type":          record.Type,
+					"value":         record.Value,
+					"ttl":           record.TTL,
+					"mx_preference": record.MXPreference,
+					"weight":        record.Weight,
+					"port":          record.Port,
+					"description":   record.Description,
+				}
+				records = append(records, n)
+			}
+			err = d.Set("records", records)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(time.Now().UTC().Format(time.RFC3339Nano))
+		}
+	} else {
+		return diag.Errorf("Error retrieving dns records")
+	}
+	return diags
+}
+
+func dataSourceNetworkDNSRecords() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkDNSRecordsRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: `Key of the dns zone the records belong to`,
+			},
+			"filter_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `If specified, results will be filtered to host`,
+			},
+			"filter_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `If specified, results will be filtered to record type`,
+			},
+			"records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"zone": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ttl": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mx_preference": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package vergeio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NICs structure to store nic specific data in
+type NICs struct {
+	ID          int    `json:"$key,omitempty"`
+	Machine     int    `json:"machine,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Interface   string `json:"interface,omitempty"`
+	VNET        int    `json:"vnet,omitempty"`
+	MAC         string `json:"macaddress,omitempty"`
+	IPaddress   string `json:"ipaddress,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func dataSourceNICsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	opts := Options{Fields: "$key,machine,name,description,interface,vnet,macaddress,ipaddress,enabled"}
+
+	// Build filter
+	filters := []string{fmt.Sprintf("machine eq %d", d.Get("machine").(int))}
+	if fn := d.Get("filter_name"); fn != nil && fn != "" {
+		filters = append(filters, fmt.Sprintf("name eq '%s'", fn.(string)))
+	}
+	opts.Filter = strings.Join(filters, " and ")
+
+	resp, err := c.Get(NICEndpoint, &opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resp != nil {
+		if resp.StatusCode == 200 {
+			var nicsData []NICs
+			body, readerr := ioutil.ReadAll(resp.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &nicsData)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			var nics []map[string]interface{}
+
+			for _, nic := range nicsData {
+
+				n := map[string]interface{}{
+					"id":          nic.ID,
+					"machine":     nic.Machine,
+					"name":        nic.Name,
+					"description": nic.Description,
+					"interface":   nic.Interface,
+					"vnet":        nic.VNET,
+					"macaddress":  nic.MAC,
+					"ipaddress":   nic.IPaddress,
+					"enabled":     nic.Enabled,
+				}
+				nics = append(nics, n)
+			}
+			err = d.Set("nics", nics)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(time.Now().UTC().Format(time.RFC3339Nano))
+		}
+	} else {
+		return diag.Errorf("Error retrieving nics")
+	}
+	return diags
+}
+
+func dataSourceNICs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNICsRead,
+		Schema: map[string]*schema.Schema{
+			"machine": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: `Machine key of the virtual machine the nics are attached to`,
+			},
+			"filter_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `If specified, results will be filtered to name`,
+			},
+			"nics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"machine": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interface": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vnet": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"macaddress": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipaddress": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
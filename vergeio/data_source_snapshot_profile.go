@@ -0,0 +1,120 @@
+package vergeio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSnapshotProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	opts := Options{
+		Fields: "$key,name,description",
+		Filter: fmt.Sprintf("name eq '%s'", d.Get("name").(string)),
+	}
+
+	resp, err := c.Get(SnapshotProfileEndpoint, &opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resp != nil {
+		if resp.StatusCode == 200 {
+			var profiles []SnapshotProfile
+			body, readerr := ioutil.ReadAll(resp.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &profiles)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			if len(profiles) != 1 {
+				return diag.Errorf("Expected 1 snapshot profile named %q, found %d", d.Get("name").(string), len(profiles))
+			}
+			profile := profiles[0]
+
+			periods, err := getSnapshotProfilePeriods(c, profile.Key)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			d.Set("description", profile.Description)
+			d.Set("period", flattenSnapshotProfilePeriods(periods))
+			d.SetId(strconv.Itoa(profile.Key))
+		}
+	} else {
+		return diag.Errorf("Error retrieving snapshot profiles")
+	}
+	return diags
+}
+
+func dataSourceSnapshotProfile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSnapshotProfileRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `Name of the snapshot profile to look up`,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"period": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"frequency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"minute": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"hour": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"day_of_week": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"day_of_month": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"retention": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_snapshots": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"immutable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,12 +1,16 @@
 package vergeio
 
 import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Provider -
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		ConfigureFunc: providerConfigure,
 		Schema: map[string]*schema.Schema{
 			"host": {
@@ -32,25 +36,75 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: "Disable SSL certificate verification",
 			},
+			"tenant": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VERGEIO_TENANT", ""),
+				Description: "Key or name of a tenant of host to manage through the tenant proxy instead of host itself",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"vergeio_vm":      resourceVM(),
-			"vergeio_drive":   resourceDrive(),
-			"vergeio_nic":     resourceNIC(),
-			"vergeio_user":    resourceUser(),
-			"vergeio_member":  resourceMember(),
-			"vergeio_network": resourceNetwork(),
+			"vergeio_auth_source":           resourceAuthSource(),
+			"vergeio_api_key":               resourceAPIKey(),
+			"vergeio_cloud_snapshot":        resourceCloudSnapshot(),
+			"vergeio_vm":                    resourceVM(),
+			"vergeio_drive":                 resourceDrive(),
+			"vergeio_nic":                   resourceNIC(),
+			"vergeio_tenant":                resourceTenant(),
+			"vergeio_tenant_ip":             resourceTenantIP(),
+			"vergeio_tenant_network_block":  resourceTenantNetworkBlock(),
+			"vergeio_tenant_node":           resourceTenantNode(),
+			"vergeio_tenant_storage":        resourceTenantStorage(),
+			"vergeio_user":                  resourceUser(),
+			"vergeio_group":                 resourceGroup(),
+			"vergeio_group_members":         resourceGroupMembers(),
+			"vergeio_ipsec_connection":      resourceIPsecConnection(),
+			"vergeio_ipsec_policy":          resourceIPsecPolicy(),
+			"vergeio_member":                resourceMember(),
+			"vergeio_network":               resourceNetwork(),
+			"vergeio_network_bgp_router":    resourceNetworkBGPRouter(),
+			"vergeio_network_bgp_neighbor":  resourceNetworkBGPNeighbor(),
+			"vergeio_network_bgp_network":   resourceNetworkBGPNetwork(),
+			"vergeio_network_bgp_route_map": resourceNetworkBGPRouteMap(),
+			"vergeio_network_dns_zone":      resourceNetworkDNSZone(),
+			"vergeio_network_dns_record":    resourceNetworkDNSRecord(),
+			"vergeio_network_host":          resourceNetworkHost(),
+			"vergeio_network_ip":            resourceNetworkIP(),
+			"vergeio_network_route":         resourceNetworkRoute(),
+			"vergeio_network_rule":          resourceNetworkRule(),
+			"vergeio_permission":            resourcePermission(),
+			"vergeio_port_forward":          resourcePortForward(),
+			"vergeio_snapshot_profile":      resourceSnapshotProfile(),
+			"vergeio_wireguard_interface":   resourceWireguardInterface(),
+			"vergeio_wireguard_peer":        resourceWireguardPeer(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"vergeio_version":      dataSourceVersion(),
-			"vergeio_clusters":     dataSourceClusters(),
-			"vergeio_mediasources": dataSourceMediaSources(),
-			"vergeio_nodes":        dataSourceNodes(),
-			"vergeio_networks":     dataSourceNetworks(),
-			"vergeio_groups":       dataSourceGroups(),
-			"vergeio_vms":			dataSourceVMs(),
+			"vergeio_auth_source":         dataSourceAuthSource(),
+			"vergeio_version":             dataSourceVersion(),
+			"vergeio_clusters":            dataSourceClusters(),
+			"vergeio_cloud_snapshots":     dataSourceCloudSnapshots(),
+			"vergeio_mediasources":        dataSourceMediaSources(),
+			"vergeio_nodes":               dataSourceNodes(),
+			"vergeio_snapshot_profile":    dataSourceSnapshotProfile(),
+			"vergeio_networks":            dataSourceNetworks(),
+			"vergeio_groups":              dataSourceGroups(),
+			"vergeio_vms":                 dataSourceVMs(),
+			"vergeio_drives":              dataSourceDrives(),
+			"vergeio_nics":                dataSourceNICs(),
+			"vergeio_network_dns_records": dataSourceNetworkDNSRecords(),
 		},
 	}
+	for name, r := range provider.ResourcesMap {
+		if tenantManagementResources[name] {
+			withTenantClient(r, true)
+			continue
+		}
+		withTenantContext(r, true)
+	}
+	for _, r := range provider.DataSourcesMap {
+		withTenantContext(r, false)
+	}
+	return provider
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -59,6 +113,141 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Password: d.Get("password").(string),
 		Host:     d.Get("host").(string),
 		Insecure: d.Get("insecure").(bool),
+		Tenant:   d.Get("tenant").(string),
 	}
 	return &client, nil
 }
+
+// tenantManagementResources manage the tenants themselves, they follow the
+// provider tenant and do not get a tenant_context argument
+var tenantManagementResources = map[string]bool{
+	"vergeio_tenant":               true,
+	"vergeio_tenant_ip":            true,
+	"vergeio_tenant_network_block": true,
+	"vergeio_tenant_node":          true,
+	"vergeio_tenant_storage":       true,
+}
+
+// withTenantContext adds the tenant_context argument to r and runs its operations
+// with the client of that tenant, or of the provider tenant when it is not set
+func withTenantContext(r *schema.Resource, resource bool) {
+	r.Schema["tenant_context"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    resource,
+		Description: "Key or name of the tenant to manage this object in, overriding the provider tenant. An empty value manages it on host",
+	}
+	withTenantClient(r, resource)
+}
+
+// withTenantClient runs the operations of r with the client of its tenant_context,
+// or of the provider tenant when it is not set. Resources record that tenant in
+// managed_tenant on create and keep using it, so the object is replaced rather
+// than looked up in another system when the tenant it targets changes.
+func withTenantClient(r *schema.Resource, resource bool) {
+	if resource {
+		r.Schema["managed_tenant"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Key or name of the tenant the object was created in, empty for host",
+		}
+	}
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, create bool) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			tenant, ok := tenantContext(d.GetRawConfig(), d.GetRawState())
+			tenant = effectiveTenant(m.(*Client), tenant, ok)
+			if resource {
+				if create {
+					d.Set("managed_tenant", tenant)
+				} else {
+					tenant = d.Get("managed_tenant").(string)
+				}
+			}
+			c, err := tenantClient(m.(*Client), tenant)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, c)
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext, true)
+	r.ReadContext = wrap(r.ReadContext, false)
+	r.UpdateContext = wrap(r.UpdateContext, false)
+	r.DeleteContext = wrap(r.DeleteContext, false)
+
+	customizeDiff := r.CustomizeDiff
+	if customizeDiff == nil && !resource {
+		return
+	}
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		// The tenant may not exist yet, leave the checks to the next plan
+		if !d.NewValueKnown("tenant_context") {
+			if resource {
+				return d.SetNewComputed("managed_tenant")
+			}
+			return nil
+		}
+		tenant, ok := tenantContext(d.GetRawConfig(), d.GetRawState())
+		tenant = effectiveTenant(m.(*Client), tenant, ok)
+		if resource && d.Get("managed_tenant").(string) != tenant {
+			err := d.SetNew("managed_tenant", tenant)
+			if err != nil {
+				return err
+			}
+			if d.Id() != "" {
+				err = d.ForceNew("managed_tenant")
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		c, err := tenantClient(m.(*Client), tenant)
+		if err != nil {
+			return err
+		}
+		return customizeDiff(ctx, d, c)
+	}
+}
+
+// effectiveTenant returns the tenant an object with the given tenant_context
+// targets, falling back to the provider tenant when it is not set
+func effectiveTenant(c *Client, tenant string, set bool) string {
+	if !set {
+		return c.Tenant
+	}
+	return tenant
+}
+
+// tenantClient returns the client operations on tenant go through, c itself for host
+func tenantClient(c *Client, tenant string) (*Client, error) {
+	if tenant == "" {
+		return c, nil
+	}
+	return c.TenantClient(tenant)
+}
+
+// tenantContext returns the tenant_context of an object from its raw config, or
+// its raw state when there is no config, and whether it is set. Get cannot tell
+// an explicit empty value, which targets host, apart from an unset one.
+func tenantContext(config, state cty.Value) (string, bool) {
+	for _, v := range []cty.Value{config, state} {
+		if v.IsNull() || !v.IsKnown() {
+			continue
+		}
+		if !v.Type().HasAttribute("tenant_context") {
+			return "", false
+		}
+		tenant := v.GetAttr("tenant_context")
+		if tenant.IsNull() || !tenant.IsKnown() {
+			return "", false
+		}
+		return tenant.AsString(), true
+	}
+	return "", false
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -42,6 +43,98 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestTenantContext(t *testing.T) {
+	object := func(tenant cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("test"), "tenant_context": tenant})
+	}
+	null := cty.NullVal(cty.Object(map[string]cty.Type{"name": cty.String, "tenant_context": cty.String}))
+	cases := []struct {
+		name    string
+		config  cty.Value
+		state   cty.Value
+		want    string
+		wantSet bool
+	}{
+		{"unset", object(cty.NullVal(cty.String)), null, "", false},
+		{"tenant", object(cty.StringVal("customer-a")), null, "customer-a", true},
+		{"explicit host", object(cty.StringVal("")), null, "", true},
+		{"config wins over state", object(cty.NullVal(cty.String)), object(cty.StringVal("customer-a")), "", false},
+		{"state without config", null, object(cty.StringVal("")), "", true},
+		{"no tenant_context argument", cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("test")}), null, "", false},
+		{"nothing", null, null, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, set := tenantContext(tc.config, tc.state)
+			if got != tc.want || set != tc.wantSet {
+				t.Errorf("tenantContext() = %q, %v, want %q, %v", got, set, tc.want, tc.wantSet)
+			}
+		})
+	}
+}
+
+func TestTenantManagementResources(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		_, ok := r.Schema["tenant_context"]
+		if ok == tenantManagementResources[name] {
+			t.Errorf("%s has tenant_context = %v", name, ok)
+		}
+	}
+}
+
+func TestManagedTenantDiff(t *testing.T) {
+	cases := []struct {
+		name           string
+		providerTenant string
+		managedTenant  string
+		tenantContext  cty.Value
+		wantNew        bool
+	}{
+		{name: "host", tenantContext: cty.NullVal(cty.String)},
+		{name: "provider tenant", providerTenant: "customer-a", managedTenant: "customer-a", tenantContext: cty.NullVal(cty.String)},
+		{name: "provider tenant changed", providerTenant: "customer-b", managedTenant: "customer-a", tenantContext: cty.NullVal(cty.String), wantNew: true},
+		{name: "provider tenant set on host object", providerTenant: "customer-a", tenantContext: cty.NullVal(cty.String), wantNew: true},
+		{name: "tenant_context", providerTenant: "customer-b", managedTenant: "customer-a", tenantContext: cty.StringVal("customer-a")},
+		{name: "explicit host", providerTenant: "customer-a", tenantContext: cty.StringVal("")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Provider().ResourcesMap["vergeio_group"]
+			attributes := map[string]string{
+				"name":           "test",
+				"enabled":        "true",
+				"managed_tenant": tc.managedTenant,
+			}
+			if !tc.tenantContext.IsNull() {
+				attributes["tenant_context"] = tc.tenantContext.AsString()
+			}
+			raw := map[string]cty.Value{}
+			for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+				raw[name] = cty.NullVal(ty)
+			}
+			raw["name"] = cty.StringVal("test")
+			raw["tenant_context"] = tc.tenantContext
+			state := &terraform.InstanceState{
+				ID:         "1",
+				Attributes: attributes,
+				RawConfig:  cty.ObjectVal(raw),
+				RawState:   cty.ObjectVal(raw),
+			}
+			config := map[string]interface{}{"name": "test"}
+			if !tc.tenantContext.IsNull() {
+				config["tenant_context"] = tc.tenantContext.AsString()
+			}
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), &Client{Tenant: tc.providerTenant})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := diff != nil && diff.RequiresNew(); got != tc.wantNew {
+				t.Errorf("requires new = %v, want %v", got, tc.wantNew)
+			}
+		})
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("VERGEIO_HOST"); v == "" {
 		t.Fatal("VERGEIO_HOST must be set for acceptance tests")
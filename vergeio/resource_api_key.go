@@ -0,0 +1,255 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// APIKeyEndpoint is the api endpoint representing this resource
+const APIKeyEndpoint = "api/v4/user_api_keys"
+
+// APIKey is the data structure for api keys of a user in vergeos
+type APIKey struct {
+	User        int     `json:"user,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Expires     int64   `json:"expires,omitempty"`
+	IPAllowList *string `json:"ip_allow_list,omitempty"`
+}
+
+// APIKeyResponse is returned when an api key is created, the only time its secret is available
+type APIKeyResponse struct {
+	Key    string `json:"$key,omitempty"`
+	Error  string `json:"err,omitempty"`
+	Secret string `json:"private_key,omitempty"`
+}
+
+func newAPIKeyFromResource(d *schema.ResourceData) *APIKey {
+	apiKey := &APIKey{}
+	if d.HasChange("user") {
+		apiKey.User = d.Get("user").(int)
+	}
+	if d.HasChange("name") {
+		apiKey.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		apiKey.Description = d.Get("description").(string)
+	}
+	if d.HasChange("expiration") {
+		if expiration, err := time.Parse(time.RFC3339, d.Get("expiration").(string)); err == nil {
+			apiKey.Expires = expiration.Unix()
+		}
+	}
+	if d.HasChange("ip_allow_list") {
+		var allowList []string
+		for _, ip := range d.Get("ip_allow_list").([]interface{}) {
+			allowList = append(allowList, ip.(string))
+		}
+		ipAllowList := strings.Join(allowList, ",")
+		apiKey.IPAllowList = &ipAllowList
+	}
+	return apiKey
+}
+
+func resourceAPIKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAPIKeyCreate,
+		ReadContext:   resourceAPIKeyRead,
+		UpdateContext: resourceAPIKeyUpdate,
+		DeleteContext: resourceAPIKeyDelete,
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"expiration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339,
+				Description:      "Time the key expires, in RFC3339 format. The key never expires when not set",
+			},
+			"ip_allow_list": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				},
+				Description: "Addresses or networks the key may be used from. Any address is allowed when empty",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary value, changing it replaces the key with a new secret",
+			},
+			"secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceAPIKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newAPIKeyFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		APIKeyEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceAPIKeyRead(ctx, d, m)
+}
+
+func resourceAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newAPIKeyFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(APIKeyEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp APIKeyResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+	d.Set("secret", resp.Secret)
+	return resourceAPIKeyRead(ctx, d, m)
+}
+
+func resourceAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		APIKeyEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "user,name,description,expires,ip_allow_list"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var apiKey APIKey
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &apiKey)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", apiKey)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving api key data")
+	}
+
+	var allowList []string
+	if apiKey.IPAllowList != nil {
+		allowList = strings.FieldsFunc(*apiKey.IPAllowList, func(r rune) bool {
+			return r == ',' || r == '\n' || r == ' '
+		})
+	}
+
+	d.Set("user", apiKey.User)
+	d.Set("name", apiKey.Name)
+	d.Set("description", apiKey.Description)
+	if apiKey.Expires > 0 {
+		d.Set("expiration", time.Unix(apiKey.Expires, 0).UTC().Format(time.RFC3339))
+	} else {
+		d.Set("expiration", "")
+	}
+	d.Set("ip_allow_list", allowList)
+	return diags
+}
+
+func resourceAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		APIKeyEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// suppressEquivalentRFC3339 suppresses diffs between timestamps that refer to the same instant
+func suppressEquivalentRFC3339(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
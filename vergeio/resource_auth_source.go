@@ -0,0 +1,253 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// AuthSourceEndpoint is the api endpoint representing this resource
+const AuthSourceEndpoint = "api/v4/authentication_sources"
+
+// AuthSource is the data structure for authorization sources in vergeos
+type AuthSource struct {
+	Key      int                `json:"$key,omitempty"`
+	Name     string             `json:"name,omitempty"`
+	Driver   string             `json:"driver,omitempty"`
+	Settings AuthSourceSettings `json:"settings"`
+}
+
+// AuthSourceSettings is the data structure for the driver settings of an authorization source
+type AuthSourceSettings struct {
+	ServerURL       string         `json:"server_url,omitempty"`
+	ClientID        string         `json:"client_id,omitempty"`
+	ClientSecret    string         `json:"client_secret,omitempty"`
+	Scope           string         `json:"scope,omitempty"`
+	GroupClaim      string         `json:"group_scope,omitempty"`
+	GroupMapping    map[string]int `json:"group_mapping,omitempty"`
+	AutoCreateUsers bool           `json:"create_user"`
+	UpdateGroups    bool           `json:"update_remote_groups"`
+}
+
+var authSourceDrivers = []string{
+	"openid",
+	"azure",
+	"google",
+	"okta",
+	"gitlab",
+	"github",
+}
+
+func newAuthSourceFromResource(d *schema.ResourceData) *AuthSource {
+	// settings are replaced as a whole by the api, so they are always sent in full
+	authSource := &AuthSource{
+		Settings: AuthSourceSettings{
+			ServerURL:       d.Get("server_url").(string),
+			ClientID:        d.Get("client_id").(string),
+			ClientSecret:    d.Get("client_secret").(string),
+			GroupClaim:      d.Get("group_claim").(string),
+			GroupMapping:    map[string]int{},
+			AutoCreateUsers: d.Get("auto_create_users").(bool),
+		},
+	}
+	if d.HasChange("name") {
+		authSource.Name = d.Get("name").(string)
+	}
+	if d.HasChange("driver") {
+		authSource.Driver = d.Get("driver").(string)
+	}
+	var scopes []string
+	for _, scope := range d.Get("scopes").([]interface{}) {
+		scopes = append(scopes, scope.(string))
+	}
+	authSource.Settings.Scope = strings.Join(scopes, " ")
+	for remoteGroup, group := range d.Get("group_mapping").(map[string]interface{}) {
+		authSource.Settings.GroupMapping[remoteGroup] = group.(int)
+	}
+	authSource.Settings.UpdateGroups = len(authSource.Settings.GroupMapping) > 0
+	return authSource
+}
+
+func resourceAuthSource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAuthSourceCreate,
+		ReadContext:   resourceAuthSourceRead,
+		UpdateContext: resourceAuthSourceUpdate,
+		DeleteContext: resourceAuthSourceDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"driver": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(authSourceDrivers, false),
+			},
+			"server_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "Issuer or base URL of the identity provider",
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Client secret. Only sent to the API, never read back",
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"group_claim": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Claim holding the remote groups of a user",
+			},
+			"group_mapping": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Remote group names mapped to the keys of the groups their members are added to",
+			},
+			"auto_create_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceAuthSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newAuthSourceFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		AuthSourceEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceAuthSourceRead(ctx, d, m)
+}
+
+func resourceAuthSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newAuthSourceFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(AuthSourceEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+	return resourceAuthSourceRead(ctx, d, m)
+}
+
+func resourceAuthSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		AuthSourceEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "$key,name,driver,settings"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var authSource AuthSource
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &authSource)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving auth source data")
+	}
+
+	d.Set("name", authSource.Name)
+	d.Set("driver", authSource.Driver)
+	d.Set("server_url", authSource.Settings.ServerURL)
+	d.Set("client_id", authSource.Settings.ClientID)
+	d.Set("scopes", strings.Fields(authSource.Settings.Scope))
+	d.Set("group_claim", authSource.Settings.GroupClaim)
+	d.Set("group_mapping", authSource.Settings.GroupMapping)
+	d.Set("auto_create_users", authSource.Settings.AutoCreateUsers)
+	return diags
+}
+
+func resourceAuthSourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		AuthSourceEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
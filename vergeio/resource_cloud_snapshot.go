@@ -0,0 +1,247 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// CloudSnapshotEndpoint is the api endpoint representing this resource
+const CloudSnapshotEndpoint = "api/v4/cloud_snapshots"
+
+// CloudSnapshot is the data structure for a system wide snapshot in vergeos
+type CloudSnapshot struct {
+	Key         int    `json:"$key,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Created     int64  `json:"created,omitempty"`
+	Expires     *int64 `json:"expires,omitempty"`
+	Immutable   bool   `json:"immutable"`
+	Private     bool   `json:"private"`
+}
+
+func newCloudSnapshotFromResource(d *schema.ResourceData) *CloudSnapshot {
+	snapshot := &CloudSnapshot{
+		Immutable: d.Get("immutable").(bool),
+		Private:   d.Get("private").(bool),
+	}
+	if d.HasChange("name") {
+		snapshot.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		snapshot.Description = d.Get("description").(string)
+	}
+	if d.HasChange("expiration") {
+		var expires int64
+		if expiration, err := time.Parse(time.RFC3339, d.Get("expiration").(string)); err == nil {
+			expires = expiration.Unix()
+		}
+		snapshot.Expires = &expires
+	}
+	return snapshot
+}
+
+func resourceCloudSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCloudSnapshotCreate,
+		ReadContext:   resourceCloudSnapshotRead,
+		UpdateContext: resourceCloudSnapshotUpdate,
+		DeleteContext: resourceCloudSnapshotDelete,
+		CustomizeDiff: resourceCloudSnapshotCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"expiration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339,
+				Description:      "Time the snapshot expires, in RFC3339 format. The snapshot never expires when not set",
+			},
+			"immutable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Prevent the snapshot from being deleted or modified before it expires",
+			},
+			"private": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Hide the snapshot from tenants that are exposed to cloud snapshots",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the snapshot was taken, in RFC3339 format",
+			},
+		},
+	}
+}
+
+// resourceCloudSnapshotCustomizeDiff makes sure an immutable snapshot expires and its expiration is only ever extended
+func resourceCloudSnapshotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("immutable").(bool) {
+		return nil
+	}
+	if d.NewValueKnown("expiration") && d.Get("expiration").(string) == "" {
+		return fmt.Errorf("an immutable cloud snapshot needs an expiration, it could never be deleted otherwise")
+	}
+	if d.Id() == "" || !d.HasChange("expiration") {
+		return nil
+	}
+	old, new := d.GetChange("expiration")
+	oldTime, err := time.Parse(time.RFC3339, old.(string))
+	if err != nil {
+		return nil
+	}
+	newTime, err := time.Parse(time.RFC3339, new.(string))
+	if err != nil {
+		return nil
+	}
+	if newTime.Before(oldTime) {
+		return fmt.Errorf("the expiration of an immutable cloud snapshot can only be extended, it cannot be moved from %s to %s", old.(string), new.(string))
+	}
+	return nil
+}
+
+func resourceCloudSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newCloudSnapshotFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		CloudSnapshotEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceCloudSnapshotRead(ctx, d, m)
+}
+
+func resourceCloudSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newCloudSnapshotFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(CloudSnapshotEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+	return resourceCloudSnapshotRead(ctx, d, m)
+}
+
+func resourceCloudSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		CloudSnapshotEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "$key,name,description,created,expires,immutable,private"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var snapshot CloudSnapshot
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &snapshot)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", snapshot)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving cloud snapshot data")
+	}
+
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("expiration", cloudSnapshotExpiration(snapshot))
+	d.Set("immutable", snapshot.Immutable)
+	d.Set("private", snapshot.Private)
+	d.Set("created", time.Unix(snapshot.Created, 0).UTC().Format(time.RFC3339))
+	return diags
+}
+
+func resourceCloudSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		CloudSnapshotEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// cloudSnapshotExpiration returns the RFC3339 expiration of snapshot, empty when it never expires
+func cloudSnapshotExpiration(snapshot CloudSnapshot) string {
+	if snapshot.Expires == nil || *snapshot.Expires == 0 {
+		return ""
+	}
+	return time.Unix(*snapshot.Expires, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,55 @@
+package vergeio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCloudSnapshotCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name       string
+		immutable  bool
+		current    string
+		expiration string
+		wantErr    bool
+	}{
+		{name: "mutable without expiration"},
+		{name: "immutable without expiration", immutable: true, wantErr: true},
+		{name: "immutable with expiration", immutable: true, expiration: "2030-01-01T00:00:00Z"},
+		{name: "mutable expiration moved earlier", current: "2030-01-01T00:00:00Z", expiration: "2029-01-01T00:00:00Z"},
+		{name: "immutable expiration extended", immutable: true, current: "2030-01-01T00:00:00Z", expiration: "2031-01-01T00:00:00Z"},
+		{name: "immutable expiration in another zone", immutable: true, current: "2030-01-01T00:00:00Z", expiration: "2030-01-01T01:00:00+01:00"},
+		{name: "immutable expiration moved earlier", immutable: true, current: "2030-01-01T00:00:00Z", expiration: "2029-01-01T00:00:00Z", wantErr: true},
+		{name: "immutable expiration removed", immutable: true, current: "2030-01-01T00:00:00Z", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.current != "" {
+				immutable := "false"
+				if tc.immutable {
+					immutable = "true"
+				}
+				state = &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+					"name":       "test",
+					"expiration": tc.current,
+					"immutable":  immutable,
+					"private":    "false",
+				}}
+			}
+			config := map[string]interface{}{
+				"name":      "test",
+				"immutable": tc.immutable,
+			}
+			if tc.expiration != "" {
+				config["expiration"] = tc.expiration
+			}
+			_, err := resourceCloudSnapshot().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
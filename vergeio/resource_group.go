@@ -0,0 +1,185 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Group is the data structure for groups in vergeos
+type Group struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Identity    int    `json:"identity,omitempty"`
+}
+
+func newGroupFromResource(d *schema.ResourceData) *Group {
+	group := &Group{}
+	if d.HasChange("name") {
+		group.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		group.Description = d.Get("description").(string)
+	}
+	if d.HasChange("email") {
+		group.Email = d.Get("email").(string)
+	}
+	group.Enabled = d.Get("enabled").(bool)
+	return group
+}
+
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"identity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newGroupFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		GroupsEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceGroupRead(ctx, d, m)
+}
+
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newGroupFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(GroupsEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+	return resourceGroupRead(ctx, d, m)
+}
+
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		GroupsEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "name,description,email,enabled,identity"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var group Group
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &group)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", group)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving group data")
+	}
+
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	d.Set("email", group.Email)
+	d.Set("enabled", group.Enabled)
+	d.Set("identity", group.Identity)
+	return diags
+}
+
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		GroupsEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
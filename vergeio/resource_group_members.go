@@ -0,0 +1,211 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GroupMember is the data structure for an existing membership of a group in vergeos
+type GroupMember struct {
+	Key    int    `json:"$key,omitempty"`
+	Member string `json:"member,omitempty"`
+}
+
+func resourceGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupMembersCreate,
+		ReadContext:   resourceGroupMembersRead,
+		UpdateContext: resourceGroupMembersUpdate,
+		DeleteContext: resourceGroupMembersDelete,
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Keys of the users that are members of the group",
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Keys of the groups nested in the group",
+			},
+		},
+	}
+}
+
+func resourceGroupMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	err := syncGroupMembers(client, d.Get("group").(int), groupMembersFromResource(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceGroupMembersRead(ctx, d, m)
+}
+
+func resourceGroupMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	group := d.Get("group").(int)
+	err := syncGroupMembers(c, group, groupMembersFromResource(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(group))
+	return resourceGroupMembersRead(ctx, d, m)
+}
+
+func resourceGroupMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	group, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The members list of a deleted group is empty rather than missing, so check the group itself
+	_, err = c.Get(fmt.Sprintf("%s/%d", GroupsEndpoint, group), &Options{Fields: "$key"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	members, err := getGroupMembers(c, group)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	users := []int{}
+	groups := []int{}
+	for _, member := range members {
+		parts := strings.SplitN(member.Member, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+		switch parts[0] {
+		case "users":
+			users = append(users, key)
+		case "groups":
+			groups = append(groups, key)
+		}
+	}
+
+	d.Set("group", group)
+	d.Set("users", users)
+	d.Set("groups", groups)
+	return diags
+}
+
+func resourceGroupMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	err := syncGroupMembers(client, d.Get("group").(int), map[string]bool{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// groupMembersFromResource returns the configured members in the "users/<key>"
+// and "groups/<key>" form used by the members api
+func groupMembersFromResource(d *schema.ResourceData) map[string]bool {
+	members := map[string]bool{}
+	for _, user := range d.Get("users").(*schema.Set).List() {
+		members[fmt.Sprintf("users/%d", user.(int))] = true
+	}
+	for _, group := range d.Get("groups").(*schema.Set).List() {
+		members[fmt.Sprintf("groups/%d", group.(int))] = true
+	}
+	return members
+}
+
+// getGroupMembers retrieves all memberships of group
+func getGroupMembers(c *Client, group int) ([]GroupMember, error) {
+	request, err := c.Get(MemberEndpoint, &Options{
+		Fields: "$key,member",
+		Filter: fmt.Sprintf("parent_group eq %d", group),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var members []GroupMember
+	err = json.Unmarshal(body, &members)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// syncGroupMembers makes the memberships of group match members exactly,
+// removing anyone not listed
+func syncGroupMembers(c *Client, group int, members map[string]bool) error {
+	current, err := getGroupMembers(c, group)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, member := range current {
+		if members[member.Member] {
+			existing[member.Member] = true
+			continue
+		}
+		_, err = c.Delete(fmt.Sprintf("%s/%d", MemberEndpoint, member.Key))
+		if err != nil {
+			return err
+		}
+	}
+	for member := range members {
+		if existing[member] {
+			continue
+		}
+		bytedata, err := json.Marshal(&Member{
+			Group:  group,
+			Member: member,
+		})
+		if err != nil {
+			return err
+		}
+		request, err := c.Post(MemberEndpoint, bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return err
+		}
+		var resp VergeResponse
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf(resp.Error)
+		}
+	}
+	return nil
+}
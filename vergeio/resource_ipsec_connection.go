@@ -0,0 +1,444 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// IPsecConnectionEndpoint is the api endpoint representing this resource
+const IPsecConnectionEndpoint = "api/v4/vnet_ipsec_phase1s"
+
+// NetworkIPsecEndpoint is the api endpoint representing the ipsec configuration of a vnet
+const NetworkIPsecEndpoint = "api/v4/vnet_ipsecs"
+
+// IPsecConnection is the data structure for ipsec connections (phase 1) on a vnet in vergeos
+type IPsecConnection struct {
+	IPsec         int    `json:"ipsec,omitempty"`
+	VNET          int    `json:"vnet,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	KeyExchange   string `json:"keyexchange,omitempty"`
+	RemoteGateway string `json:"remote_gateway,omitempty"`
+	AuthMethod    string `json:"auth,omitempty"`
+	PSK           string `json:"psk,omitempty"`
+	Negotiation   string `json:"negotiation,omitempty"`
+	Encryption    string `json:"encryption,omitempty"`
+	Hash          string `json:"hash,omitempty"`
+	DHGroup       int    `json:"dhgroup,omitempty"`
+	Lifetime      int    `json:"lifetime,omitempty"`
+}
+
+// NetworkIPsec is the data structure for the ipsec configuration of a vnet in vergeos
+type NetworkIPsec struct {
+	Key  int `json:"$key,omitempty"`
+	VNET int `json:"vnet,omitempty"`
+}
+
+var ipsecEncryptionAlgorithms = []string{
+	"aes128",
+	"aes192",
+	"aes256",
+	"aes128gcm128",
+	"aes256gcm128",
+	"3des",
+}
+
+var ipsecHashAlgorithms = []string{
+	"sha1",
+	"sha256",
+	"sha384",
+	"sha512",
+	"md5",
+}
+
+var ipsecDHGroups = []int{1, 2, 5, 14, 15, 16, 17, 18, 19, 20, 21}
+
+func newIPsecConnectionFromResource(d *schema.ResourceData) *IPsecConnection {
+	connection := &IPsecConnection{
+		AuthMethod: "psk",
+	}
+	if d.HasChange("name") {
+		connection.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		connection.Description = d.Get("description").(string)
+	}
+	connection.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("key_exchange") {
+		connection.KeyExchange = d.Get("key_exchange").(string)
+	}
+	if d.HasChange("remote_gateway") {
+		connection.RemoteGateway = d.Get("remote_gateway").(string)
+	}
+	if d.HasChange("psk") {
+		connection.PSK = d.Get("psk").(string)
+	}
+	if d.HasChange("negotiation") {
+		connection.Negotiation = d.Get("negotiation").(string)
+	}
+	if d.HasChange("encryption") {
+		connection.Encryption = d.Get("encryption").(string)
+	}
+	if d.HasChange("hash") {
+		connection.Hash = d.Get("hash").(string)
+	}
+	if d.HasChange("dh_group") {
+		connection.DHGroup = d.Get("dh_group").(int)
+	}
+	if d.HasChange("lifetime") {
+		connection.Lifetime = d.Get("lifetime").(int)
+	}
+	return connection
+}
+
+func resourceIPsecConnection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIPsecConnectionCreate,
+		ReadContext:   resourceIPsecConnectionRead,
+		UpdateContext: resourceIPsecConnectionUpdate,
+		DeleteContext: resourceIPsecConnectionDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"ipsec": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ipsec_created": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the ipsec configuration of the vnet was created for this connection, it is then removed with the connection when no other connections remain",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"key_exchange": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "ikev2",
+				ValidateFunc: validation.StringInSlice([]string{
+					"ike",
+					"ikev1",
+					"ikev2",
+				}, false),
+			},
+			"remote_gateway": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"psk": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Pre-shared key. Only sent to the API, never read back",
+			},
+			"negotiation": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"main",
+					"aggressive",
+				}, false),
+			},
+			"encryption": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "aes256",
+				ValidateFunc: validation.StringInSlice(ipsecEncryptionAlgorithms, false),
+			},
+			"hash": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "sha256",
+				ValidateFunc: validation.StringInSlice(ipsecHashAlgorithms, false),
+			},
+			"dh_group": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      14,
+				ValidateFunc: validation.IntInSlice(ipsecDHGroups),
+			},
+			"lifetime": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      28800,
+				ValidateFunc: validation.IntBetween(120, 86400),
+				Description:  "Phase 1 lifetime in seconds",
+			},
+		},
+	}
+}
+
+func resourceIPsecConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newIPsecConnectionFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		IPsecConnectionEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceIPsecConnectionRead(ctx, d, m)
+}
+
+func resourceIPsecConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	unlock := lockNetwork(d.Get("vnet").(int))
+	defer unlock()
+	ipsec, created, err := getNetworkIPsec(c, d.Get("vnet").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("ipsec_created", created)
+
+	resource := newIPsecConnectionFromResource(d)
+	resource.IPsec = ipsec
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(IPsecConnectionEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceIPsecConnectionRead(ctx, d, m)
+}
+
+func resourceIPsecConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		IPsecConnectionEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "ipsec,ipsec#vnet as vnet,name,description,enabled,keyexchange,remote_gateway,auth,negotiation,encryption,hash,dhgroup,lifetime"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var connection IPsecConnection
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &connection)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", connection)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving ipsec connection data")
+	}
+
+	d.Set("vnet", connection.VNET)
+	d.Set("ipsec", connection.IPsec)
+	d.Set("name", connection.Name)
+	d.Set("description", connection.Description)
+	d.Set("enabled", connection.Enabled)
+	d.Set("key_exchange", connection.KeyExchange)
+	d.Set("remote_gateway", connection.RemoteGateway)
+	d.Set("negotiation", connection.Negotiation)
+	d.Set("encryption", connection.Encryption)
+	d.Set("hash", connection.Hash)
+	d.Set("dh_group", connection.DHGroup)
+	d.Set("lifetime", connection.Lifetime)
+	return diags
+}
+
+func resourceIPsecConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	unlock := lockNetwork(d.Get("vnet").(int))
+	defer unlock()
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		IPsecConnectionEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("ipsec_created").(bool) {
+		err = deleteNetworkIPsec(client, d.Get("ipsec").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getNetworkIPsec returns the key of the ipsec configuration of vnet and whether
+// it was created because the vnet had none
+func getNetworkIPsec(c *Client, vnet int) (int, bool, error) {
+	request, err := c.Get(NetworkIPsecEndpoint, &Options{
+		Fields: "$key,vnet",
+		Filter: fmt.Sprintf("vnet eq %d", vnet),
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	var ipsecs []NetworkIPsec
+	err = json.Unmarshal(body, &ipsecs)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(ipsecs) > 0 {
+		return ipsecs[0].Key, false, nil
+	}
+
+	bytedata, err := json.Marshal(&NetworkIPsec{VNET: vnet})
+	if err != nil {
+		return 0, false, err
+	}
+	request, err = c.Post(NetworkIPsecEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return 0, false, err
+	}
+	body, err = ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	var resp VergeResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return 0, false, err
+	}
+	if resp.Error != "" {
+		return 0, false, fmt.Errorf(resp.Error)
+	}
+	ipsec, err := strconv.Atoi(resp.Key)
+	return ipsec, true, err
+}
+
+// deleteNetworkIPsec removes the ipsec configuration with key ipsec when it holds no connections
+func deleteNetworkIPsec(c *Client, ipsec int) error {
+	request, err := c.Get(IPsecConnectionEndpoint, &Options{
+		Fields: "$key",
+		Filter: fmt.Sprintf("ipsec eq %d", ipsec),
+	})
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	var connections []IPsecConnection
+	err = json.Unmarshal(body, &connections)
+	if err != nil {
+		return err
+	}
+	if len(connections) > 0 {
+		return nil
+	}
+	_, err = c.Delete(fmt.Sprintf("%s/%d", NetworkIPsecEndpoint, ipsec))
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		return nil
+	}
+	return err
+}
+
+// getIPsecConnection retrieves a single ipsec connection by its key
+func getIPsecConnection(c *Client, connection int) (*IPsecConnection, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		IPsecConnectionEndpoint,
+		connection,
+	), &Options{Fields: "ipsec,ipsec#vnet as vnet,name"})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var ipsecConnection IPsecConnection
+	err = json.Unmarshal(body, &ipsecConnection)
+	if err != nil {
+		return nil, err
+	}
+	return &ipsecConnection, nil
+}
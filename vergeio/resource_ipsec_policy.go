@@ -0,0 +1,290 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// IPsecPolicyEndpoint is the api endpoint representing this resource
+const IPsecPolicyEndpoint = "api/v4/vnet_ipsec_phase2s"
+
+// IPsecPolicy is the data structure for ipsec policies (phase 2) of an ipsec connection in vergeos
+type IPsecPolicy struct {
+	Connection    int    `json:"phase1,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	Mode          string `json:"mode,omitempty"`
+	LocalNetwork  string `json:"local_network,omitempty"`
+	RemoteNetwork string `json:"remote_network,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
+	Encryption    string `json:"encryption,omitempty"`
+	Hash          string `json:"hash,omitempty"`
+	PFSGroup      int    `json:"dhgroup"`
+	Lifetime      int    `json:"lifetime,omitempty"`
+}
+
+func newIPsecPolicyFromResource(d *schema.ResourceData) *IPsecPolicy {
+	policy := &IPsecPolicy{}
+	if d.HasChange("ipsec_connection") {
+		policy.Connection = d.Get("ipsec_connection").(int)
+	}
+	if d.HasChange("description") {
+		policy.Description = d.Get("description").(string)
+	}
+	policy.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("mode") {
+		policy.Mode = d.Get("mode").(string)
+	}
+	if d.HasChange("local_network") {
+		policy.LocalNetwork = d.Get("local_network").(string)
+	}
+	if d.HasChange("remote_network") {
+		policy.RemoteNetwork = d.Get("remote_network").(string)
+	}
+	if d.HasChange("protocol") {
+		policy.Protocol = d.Get("protocol").(string)
+	}
+	if d.HasChange("encryption") {
+		policy.Encryption = d.Get("encryption").(string)
+	}
+	if d.HasChange("hash") {
+		policy.Hash = d.Get("hash").(string)
+	}
+	policy.PFSGroup = d.Get("pfs_group").(int)
+	if d.HasChange("lifetime") {
+		policy.Lifetime = d.Get("lifetime").(int)
+	}
+	return policy
+}
+
+func resourceIPsecPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIPsecPolicyCreate,
+		ReadContext:   resourceIPsecPolicyRead,
+		UpdateContext: resourceIPsecPolicyUpdate,
+		DeleteContext: resourceIPsecPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"ipsec_connection": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tunnel",
+				ValidateFunc: validation.StringInSlice([]string{
+					"tunnel",
+					"transport",
+				}, false),
+			},
+			"local_network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"remote_network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "esp",
+				ValidateFunc: validation.StringInSlice([]string{
+					"esp",
+					"ah",
+				}, false),
+			},
+			"encryption": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "aes256",
+				ValidateFunc: validation.StringInSlice(ipsecEncryptionAlgorithms, false),
+			},
+			"hash": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "sha256",
+				ValidateFunc: validation.StringInSlice(ipsecHashAlgorithms, false),
+			},
+			"pfs_group": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      14,
+				ValidateFunc: validation.IntInSlice(append([]int{0}, ipsecDHGroups...)),
+				Description:  "DH group used for perfect forward secrecy, 0 disables it",
+			},
+			"lifetime": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				ValidateFunc: validation.IntBetween(120, 86400),
+				Description:  "Phase 2 lifetime in seconds",
+			},
+		},
+	}
+}
+
+func resourceIPsecPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newIPsecPolicyFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		IPsecPolicyEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = applyIPsecConnection(client, d.Get("ipsec_connection").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceIPsecPolicyRead(ctx, d, m)
+}
+
+func resourceIPsecPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newIPsecPolicyFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(IPsecPolicyEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = applyIPsecConnection(c, d.Get("ipsec_connection").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceIPsecPolicyRead(ctx, d, m)
+}
+
+func resourceIPsecPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		IPsecPolicyEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var policy IPsecPolicy
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &policy)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", policy)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving ipsec policy data")
+	}
+
+	d.Set("ipsec_connection", policy.Connection)
+	d.Set("description", policy.Description)
+	d.Set("enabled", policy.Enabled)
+	d.Set("mode", policy.Mode)
+	d.Set("local_network", policy.LocalNetwork)
+	d.Set("remote_network", policy.RemoteNetwork)
+	d.Set("protocol", policy.Protocol)
+	d.Set("encryption", policy.Encryption)
+	d.Set("hash", policy.Hash)
+	d.Set("pfs_group", policy.PFSGroup)
+	d.Set("lifetime", policy.Lifetime)
+	return diags
+}
+
+func resourceIPsecPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		IPsecPolicyEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyIPsecConnection(client, d.Get("ipsec_connection").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// applyIPsecConnection applies the pending configuration of the vnet owning connection
+func applyIPsecConnection(c *Client, connection int) error {
+	ipsecConnection, err := getIPsecConnection(c, connection)
+	if err != nil {
+		return err
+	}
+	return runNetworkAction(c, ipsecConnection.VNET, "apply")
+}
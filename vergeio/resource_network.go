@@ -7,7 +7,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,10 +22,23 @@ import (
 // NetworkEndPoint is the api endpoint representing this resource
 const NetworkEndPoint = "api/v4/vnets"
 
+// NetworkActionEndpoint is the api endpoint used to run actions against a vnet
+const NetworkActionEndpoint = "api/v4/vnet_actions"
+
 // Network is the data structure for virtual machines in vergeos
 type Network struct {
 	Name            string `json:"name,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Type            string `json:"type,omitempty"`
 	Enabled         bool   `json:"enabled"`
+	CIDR            string `json:"network,omitempty"`
+	IPaddressType   string `json:"ipaddress_type,omitempty"`
+	MTU             int    `json:"mtu,omitempty"`
+	Domain          string `json:"domain,omitempty"`
+	DNS             string `json:"dns,omitempty"`
+	Interface_VNET  int    `json:"interface_vnet,omitempty"`
+	Layer2_Type     string `json:"layer2_type,omitempty"`
+	VLAN            int    `json:"layer2_id,omitempty"`
 	Default_Gateway int    `json:"vnet_default_gateway,omitempty"`
 	IPaddress       string `json:"ipaddress,omitempty"`
 	DHCP            bool   `json:"dhcp_enabled"`
@@ -31,13 +49,68 @@ type Network struct {
 	On_Power_Loss   string `json:"on_power_loss,omitempty"`
 }
 
+// NetworkAction is the data structure for actions run against a vnet
+type NetworkAction struct {
+	VNET   int    `json:"vnet"`
+	Action string `json:"action"`
+}
+
+// NetworkStatus is the data structure for the power and pending change state of a vnet
+type NetworkStatus struct {
+	Running      bool `json:"running"`
+	NeedsRestart bool `json:"need_restart"`
+	NeedsApply   bool `json:"need_fw_apply"`
+	NeedsDNS     bool `json:"need_dns_apply"`
+}
+
+// runNetworkAction runs action against the vnet
+func runNetworkAction(c *Client, vnet int, action string) error {
+	bytedata, err := json.Marshal(&NetworkAction{
+		VNET:   vnet,
+		Action: action,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.Post(NetworkActionEndpoint, bytes.NewBuffer(bytedata))
+	return err
+}
+
 func newNetworkFromResource(d *schema.ResourceData) *Network {
 	network := &Network{}
 	if d.HasChange("name") {
 		network.Name = d.Get("name").(string)
 	}
-	if d.HasChange("enabled") {
-		network.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("description") {
+		network.Description = d.Get("description").(string)
+	}
+	if d.HasChange("type") {
+		network.Type = d.Get("type").(string)
+	}
+	network.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("cidr") {
+		network.CIDR = d.Get("cidr").(string)
+	}
+	if d.HasChange("ipaddress_type") {
+		network.IPaddressType = d.Get("ipaddress_type").(string)
+	}
+	if d.HasChange("mtu") {
+		network.MTU = d.Get("mtu").(int)
+	}
+	if d.HasChange("domain") {
+		network.Domain = d.Get("domain").(string)
+	}
+	if d.HasChange("dns") {
+		network.DNS = d.Get("dns").(string)
+	}
+	if d.HasChange("interface_network") {
+		network.Interface_VNET = d.Get("interface_network").(int)
+	}
+	if d.HasChange("layer2_type") {
+		network.Layer2_Type = d.Get("layer2_type").(string)
+	}
+	if d.HasChange("vlan") {
+		network.VLAN = d.Get("vlan").(int)
 	}
 	if d.HasChange("vnet_default_gateway") {
 		network.Default_Gateway = d.Get("vnet_default_gateway").(int)
@@ -48,8 +121,8 @@ func newNetworkFromResource(d *schema.ResourceData) *Network {
 	if d.HasChange("dhcp_enabled") {
 		network.DHCP = d.Get("dhcp_enabled").(bool)
 	}
-	if d.HasChange("dhcp_dynamic") {
-		network.Dynamic_DHCP = d.Get("dhcp_dynamic").(bool)
+	if d.HasChange("dynamic_dhcp") {
+		network.Dynamic_DHCP = d.Get("dynamic_dhcp").(bool)
 	}
 	if d.HasChange("dhcp_sequential") {
 		network.DHCP_Sequential = d.Get("dhcp_sequential").(bool)
@@ -72,15 +145,96 @@ func resourceNetwork() *schema.Resource {
 		ReadContext:   resourceNetworkRead,
 		UpdateContext: resourceNetworkUpdate,
 		DeleteContext: resourceNetworkDelete,
+		CustomizeDiff: resourceNetworkCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"internal",
+					"external",
+					"dmz",
+					"vpn",
+					"bgp",
+				}, false),
+			},
 			"enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  true,
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"ipaddress_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"static",
+					"dhcp",
+					"none",
+				}, false),
+			},
+			"mtu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1000, 9216),
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"dns": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"disabled",
+					"simple",
+					"bind",
+				}, false),
+			},
+			"interface_network": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"layer2_type": {
+				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"none",
+					"vlan",
+					"vxlan",
+				}, false),
+			},
+			"vlan": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 4094),
 			},
 			"vnet_default_gateway": {
 				Type:     schema.TypeInt,
@@ -106,12 +260,14 @@ func resourceNetwork() *schema.Resource {
 				Computed: true,
 			},
 			"dhcp_start": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
 			},
 			"dhcp_stop": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
 			},
 			"on_power_loss": {
 				Type: schema.TypeString,
@@ -122,6 +278,14 @@ func resourceNetwork() *schema.Resource {
 				}, false),
 				Optional: true,
 			},
+			"running": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"needs_restart": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -147,6 +311,11 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if req.StatusCode != 200 {
 		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
 	}
+
+	err = syncNetworkPower(ctx, client, d, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceNetworkRead(ctx, d, m)
 }
 
@@ -176,6 +345,11 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.Errorf(resp.Error)
 	}
 	d.SetId(string(resp.Key))
+
+	err = syncNetworkPower(ctx, c, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceNetworkRead(ctx, d, m)
 }
 
@@ -219,15 +393,36 @@ func resourceNetworkRead(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	d.Set("name", network.Name)
+	d.Set("description", network.Description)
+	d.Set("type", network.Type)
 	d.Set("enabled", network.Enabled)
+	d.Set("cidr", network.CIDR)
+	d.Set("ipaddress_type", network.IPaddressType)
+	d.Set("mtu", network.MTU)
+	d.Set("domain", network.Domain)
+	d.Set("dns", network.DNS)
+	d.Set("interface_network", network.Interface_VNET)
+	d.Set("layer2_type", network.Layer2_Type)
+	d.Set("vlan", network.VLAN)
 	d.Set("vnet_default_gateway", network.Default_Gateway)
 	d.Set("ipaddress", network.IPaddress)
 	d.Set("dhcp_enabled", network.DHCP)
-	d.Set("dhcp_dynamic", network.Dynamic_DHCP)
+	d.Set("dynamic_dhcp", network.Dynamic_DHCP)
 	d.Set("dhcp_sequential", network.DHCP_Sequential)
 	d.Set("dhcp_start", network.DynamicIP_Start)
 	d.Set("dhcp_stop", network.DynamicIP_Stop)
 	d.Set("on_power_loss", network.On_Power_Loss)
+
+	vnet, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	status, err := getNetworkStatus(c, vnet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("running", status.Running)
+	d.Set("needs_restart", status.NeedsRestart)
 	return diags
 }
 
@@ -243,3 +438,174 @@ func resourceNetworkDelete(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	return diags
 }
+
+// syncNetworkPower powers the vnet on or off to match enabled. A running vnet is
+// then restarted when its DHCP or addressing changes require it, and pending
+// rules and dns changes are applied. Each action is waited for before the next
+// one is picked, until nothing is pending.
+func syncNetworkPower(ctx context.Context, c *Client, d *schema.ResourceData, timeout time.Duration) error {
+	vnet, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	enabled := d.Get("enabled").(bool)
+	powerOff := !enabled && d.HasChange("enabled")
+	ran := map[string]bool{}
+	for {
+		status, err := getNetworkStatus(c, vnet)
+		if err != nil {
+			return err
+		}
+		action, done := nextNetworkAction(status, enabled, powerOff)
+		// An action whose flag the vnet keeps reporting is not run again
+		if action == "" || ran[action] {
+			return nil
+		}
+		ran[action] = true
+
+		err = runNetworkAction(c, vnet, action)
+		if err != nil {
+			return err
+		}
+		err = waitFor(ctx, timeout, fmt.Sprintf("vnet %d to %s", vnet, action), func() (bool, error) {
+			status, err := getNetworkStatus(c, vnet)
+			if err != nil {
+				return false, err
+			}
+			return done(status), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// nextNetworkAction returns the next action a vnet in status needs and the check
+// telling it took effect, or "" when nothing is pending
+func nextNetworkAction(status *NetworkStatus, enabled bool, powerOff bool) (string, func(*NetworkStatus) bool) {
+	switch {
+	case enabled && !status.Running:
+		return "poweron", func(s *NetworkStatus) bool { return s.Running }
+	case powerOff && status.Running:
+		return "poweroff", func(s *NetworkStatus) bool { return !s.Running }
+	case !status.Running:
+		return "", nil
+	case status.NeedsRestart:
+		return "restart", func(s *NetworkStatus) bool { return s.Running && !s.NeedsRestart }
+	case status.NeedsApply:
+		return "apply", func(s *NetworkStatus) bool { return !s.NeedsApply }
+	case status.NeedsDNS:
+		return "applydns", func(s *NetworkStatus) bool { return !s.NeedsDNS }
+	}
+	return "", nil
+}
+
+// getNetworkStatus retrieves the power and pending change state of a vnet
+func getNetworkStatus(c *Client, vnet int) (*NetworkStatus, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		NetworkEndPoint,
+		vnet,
+	), &Options{Fields: "machine#status#running as running,need_restart,need_fw_apply,need_dns_apply"})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var status NetworkStatus
+	err = json.Unmarshal(body, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// resourceNetworkCustomizeDiff checks that the router and DHCP range addresses lie inside the subnet
+func resourceNetworkCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	subnet := networkSubnet(d.Get("cidr").(string), d.Get("ipaddress").(string))
+	if subnet == nil {
+		return nil
+	}
+	for _, key := range []string{"ipaddress", "dhcp_start", "dhcp_stop"} {
+		value := strings.Split(d.Get(key).(string), "/")[0]
+		if value == "" {
+			continue
+		}
+		ip := net.ParseIP(value)
+		if ip == nil || !subnet.Contains(ip) {
+			return fmt.Errorf("%s %s is not within the network subnet %s", key, value, subnet.String())
+		}
+	}
+	start := net.ParseIP(d.Get("dhcp_start").(string))
+	stop := net.ParseIP(d.Get("dhcp_stop").(string))
+	if start != nil && stop != nil && bytes.Compare(start.To16(), stop.To16()) > 0 {
+		return fmt.Errorf("dhcp_start %s is after dhcp_stop %s", start, stop)
+	}
+	return nil
+}
+
+// networkSubnet returns the subnet of a vnet from its cidr, falling back to an
+// ipaddress given in CIDR notation. nil is returned when neither is known.
+func networkSubnet(cidr string, ipaddress string) *net.IPNet {
+	if cidr == "" {
+		cidr = ipaddress
+	}
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+	return subnet
+}
+
+// getNetwork retrieves a single vnet by its key
+func getNetwork(c *Client, vnet int) (*Network, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		NetworkEndPoint,
+		vnet,
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var network Network
+	err = json.Unmarshal(body, &network)
+	if err != nil {
+		return nil, err
+	}
+	return &network, nil
+}
+
+// ipInRange reports whether ip lies between start and stop inclusive
+func ipInRange(ip net.IP, start string, stop string) bool {
+	startIP := net.ParseIP(start)
+	stopIP := net.ParseIP(stop)
+	if ip == nil || startIP == nil || stopIP == nil {
+		return false
+	}
+	return bytes.Compare(ip.To16(), startIP.To16()) >= 0 && bytes.Compare(ip.To16(), stopIP.To16()) <= 0
+}
+
+// networkLocks serializes creating and removing the objects a vnet shares between
+// resources, such as its dns views and ipsec configuration, so resources changed
+// in parallel agree on them
+var networkLocks = struct {
+	sync.Mutex
+	vnets map[int]*sync.Mutex
+}{vnets: map[int]*sync.Mutex{}}
+
+// lockNetwork locks the shared objects of vnet and returns the function releasing them
+func lockNetwork(vnet int) func() {
+	networkLocks.Lock()
+	lock, ok := networkLocks.vnets[vnet]
+	if !ok {
+		lock = &sync.Mutex{}
+		networkLocks.vnets[vnet] = lock
+	}
+	networkLocks.Unlock()
+	lock.Lock()
+	return lock.Unlock
+}
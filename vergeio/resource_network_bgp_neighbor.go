@@ -0,0 +1,340 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkBGPRouterCommandEndpoint is the api endpoint representing the configuration commands of a bgp router
+const NetworkBGPRouterCommandEndpoint = "api/v4/vnet_bgp_router_commands"
+
+// NetworkBGPRouterCommand is the data structure for configuration commands of a bgp router in vergeos
+type NetworkBGPRouterCommand struct {
+	Key     int    `json:"$key,omitempty"`
+	Router  int    `json:"bgp_router,omitempty"`
+	Command string `json:"command,omitempty"`
+	Params  string `json:"params,omitempty"`
+}
+
+func newNetworkBGPNeighborFromResource(d *schema.ResourceData) *NetworkBGPRouterCommand {
+	return &NetworkBGPRouterCommand{
+		Router:  d.Get("router").(int),
+		Command: "neighbor",
+		Params:  fmt.Sprintf("%s remote-as %d", d.Get("address").(string), d.Get("remote_asn").(int)),
+	}
+}
+
+func resourceNetworkBGPNeighbor() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkBGPNeighborCreate,
+		ReadContext:   resourceNetworkBGPNeighborRead,
+		UpdateContext: resourceNetworkBGPNeighborUpdate,
+		DeleteContext: resourceNetworkBGPNeighborDelete,
+		Schema: map[string]*schema.Schema{
+			"router": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"remote_asn": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateASN,
+			},
+			"route_map_in": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringDoesNotContainAny(" "),
+				Description:  "Tag of the route map applied to routes received from the neighbor",
+			},
+			"route_map_out": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringDoesNotContainAny(" "),
+				Description:  "Tag of the route map applied to routes advertised to the neighbor",
+			},
+		},
+	}
+}
+
+func resourceNetworkBGPNeighborUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkBGPNeighborFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterCommandEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	oldAddress, _ := d.GetChange("address")
+	err = syncNetworkBGPNeighborRouteMaps(client, d.Get("router").(int), oldAddress.(string), d.Get("address").(string), networkBGPNeighborRouteMaps(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = applyNetworkBGPRouter(client, d.Get("router").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPNeighborRead(ctx, d, m)
+}
+
+func resourceNetworkBGPNeighborCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkBGPNeighborFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkBGPRouterCommandEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = syncNetworkBGPNeighborRouteMaps(c, d.Get("router").(int), d.Get("address").(string), d.Get("address").(string), networkBGPNeighborRouteMaps(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = applyNetworkBGPRouter(c, d.Get("router").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPNeighborRead(ctx, d, m)
+}
+
+func resourceNetworkBGPNeighborRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterCommandEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var command NetworkBGPRouterCommand
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &command)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", command)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving bgp neighbor data")
+	}
+
+	var address string
+	var remoteASN int
+	_, err = fmt.Sscanf(command.Params, "%s remote-as %d", &address, &remoteASN)
+	if command.Command != "neighbor" || err != nil {
+		// The command was rewritten outside of terraform, so it no longer is this neighbor
+		log.Printf("[WARN] bgp router command %s is not a neighbor: %s %s", d.Id(), command.Command, command.Params)
+		d.SetId("")
+		return diags
+	}
+
+	routeMaps, err := getNetworkBGPNeighborRouteMaps(c, command.Router, address)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("router", command.Router)
+	d.Set("address", address)
+	d.Set("remote_asn", remoteASN)
+	d.Set("route_map_in", parseNetworkBGPNeighborRouteMap(routeMaps["in"].Params, address, "in"))
+	d.Set("route_map_out", parseNetworkBGPNeighborRouteMap(routeMaps["out"].Params, address, "out"))
+	return diags
+}
+
+func resourceNetworkBGPNeighborDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	err := syncNetworkBGPNeighborRouteMaps(client, d.Get("router").(int), d.Get("address").(string), d.Get("address").(string), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Delete(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterCommandEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyNetworkBGPRouter(client, d.Get("router").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// applyNetworkBGPRouter applies the pending configuration of the vnet owning router
+func applyNetworkBGPRouter(c *Client, router int) error {
+	bgpRouter, err := getNetworkBGPRouter(c, router)
+	if err != nil {
+		return err
+	}
+	return runNetworkAction(c, bgpRouter.VNET, "apply")
+}
+
+// networkBGPNeighborRouteMaps returns the route map tags of d by direction
+func networkBGPNeighborRouteMaps(d *schema.ResourceData) map[string]string {
+	return map[string]string{
+		"in":  d.Get("route_map_in").(string),
+		"out": d.Get("route_map_out").(string),
+	}
+}
+
+// parseNetworkBGPNeighborRouteMap returns the route map tag of a neighbor route-map
+// command for address in direction, or "" when params is not one
+func parseNetworkBGPNeighborRouteMap(params string, address string, direction string) string {
+	fields := strings.Fields(params)
+	if len(fields) != 4 || fields[0] != address || fields[1] != "route-map" || fields[3] != direction {
+		return ""
+	}
+	return fields[2]
+}
+
+// getNetworkBGPNeighborRouteMaps returns the route-map commands of router for the
+// neighbor at address by direction
+func getNetworkBGPNeighborRouteMaps(c *Client, router int, address string) (map[string]NetworkBGPRouterCommand, error) {
+	request, err := c.Get(NetworkBGPRouterCommandEndpoint, &Options{
+		Fields: "$key,bgp_router,command,params",
+		Filter: fmt.Sprintf("bgp_router eq %d and command eq 'neighbor'", router),
+		Sort:   "$key",
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var commands []NetworkBGPRouterCommand
+	err = json.Unmarshal(body, &commands)
+	if err != nil {
+		return nil, err
+	}
+	routeMaps := map[string]NetworkBGPRouterCommand{}
+	for _, command := range commands {
+		for _, direction := range []string{"in", "out"} {
+			if parseNetworkBGPNeighborRouteMap(command.Params, address, direction) != "" {
+				routeMaps[direction] = command
+			}
+		}
+	}
+	return routeMaps, nil
+}
+
+// syncNetworkBGPNeighborRouteMaps makes the route-map commands of the neighbor
+// that was at oldAddress match routeMaps for the neighbor at address
+func syncNetworkBGPNeighborRouteMaps(c *Client, router int, oldAddress string, address string, routeMaps map[string]string) error {
+	current, err := getNetworkBGPNeighborRouteMaps(c, router, oldAddress)
+	if err != nil {
+		return err
+	}
+	for _, direction := range []string{"in", "out"} {
+		params := ""
+		if routeMaps[direction] != "" {
+			params = fmt.Sprintf("%s route-map %s %s", address, routeMaps[direction], direction)
+		}
+		command, ok := current[direction]
+		if ok && command.Params == params {
+			continue
+		}
+		if ok {
+			_, err = c.Delete(fmt.Sprintf("%s/%d", NetworkBGPRouterCommandEndpoint, command.Key))
+			if err != nil {
+				return err
+			}
+		}
+		if params == "" {
+			continue
+		}
+		bytedata, err := json.Marshal(&NetworkBGPRouterCommand{
+			Router:  router,
+			Command: "neighbor",
+			Params:  params,
+		})
+		if err != nil {
+			return err
+		}
+		request, err := c.Post(NetworkBGPRouterCommandEndpoint, bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return err
+		}
+		var resp VergeResponse
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf(resp.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package vergeio
+
+import (
+	"testing"
+)
+
+func TestParseNetworkBGPNeighborRouteMap(t *testing.T) {
+	cases := []struct {
+		params    string
+		direction string
+		want      string
+	}{
+		{"192.0.2.1 route-map UPSTREAM-IN in", "in", "UPSTREAM-IN"},
+		{"192.0.2.1 route-map UPSTREAM-OUT out", "out", "UPSTREAM-OUT"},
+		{"192.0.2.1 route-map UPSTREAM-IN in", "out", ""},
+		{"192.0.2.2 route-map UPSTREAM-IN in", "in", ""},
+		{"192.0.2.1 remote-as 64500", "in", ""},
+		{"", "in", ""},
+	}
+	for _, tc := range cases {
+		got := parseNetworkBGPNeighborRouteMap(tc.params, "192.0.2.1", tc.direction)
+		if got != tc.want {
+			t.Errorf("parseNetworkBGPNeighborRouteMap(%q, %q) = %q, want %q", tc.params, tc.direction, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func newNetworkBGPNetworkFromResource(d *schema.ResourceData) *NetworkBGPRouterCommand {
+	return &NetworkBGPRouterCommand{
+		Router:  d.Get("router").(int),
+		Command: "network",
+		Params:  d.Get("prefix").(string),
+	}
+}
+
+func resourceNetworkBGPNetwork() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkBGPNetworkCreate,
+		ReadContext:   resourceNetworkBGPNetworkRead,
+		UpdateContext: resourceNetworkBGPNetworkUpdate,
+		DeleteContext: resourceNetworkBGPNetworkDelete,
+		Schema: map[string]*schema.Schema{
+			"router": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+		},
+	}
+}
+
+func resourceNetworkBGPNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkBGPNetworkFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterCommandEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = applyNetworkBGPRouter(client, d.Get("router").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPNetworkRead(ctx, d, m)
+}
+
+func resourceNetworkBGPNetworkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkBGPNetworkFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkBGPRouterCommandEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = applyNetworkBGPRouter(c, d.Get("router").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPNetworkRead(ctx, d, m)
+}
+
+func resourceNetworkBGPNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterCommandEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var command NetworkBGPRouterCommand
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &command)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", command)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving bgp network data")
+	}
+
+	d.Set("router", command.Router)
+	d.Set("prefix", command.Params)
+	return diags
+}
+
+func resourceNetworkBGPNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterCommandEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyNetworkBGPRouter(client, d.Get("router").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,228 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkBGPRouteMapEndpoint is the api endpoint representing this resource
+const NetworkBGPRouteMapEndpoint = "api/v4/vnet_bgp_route_maps"
+
+// NetworkBGPRouteMap is the data structure for bgp route maps on a vnet in vergeos
+type NetworkBGPRouteMap struct {
+	VNET        int    `json:"vnet,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Sequence    int    `json:"sequence,omitempty"`
+	Permit      bool   `json:"permit"`
+	Match       string `json:"match,omitempty"`
+	Set         string `json:"set,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func newNetworkBGPRouteMapFromResource(d *schema.ResourceData) *NetworkBGPRouteMap {
+	routeMap := &NetworkBGPRouteMap{}
+	if d.HasChange("vnet") {
+		routeMap.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("tag") {
+		routeMap.Tag = d.Get("tag").(string)
+	}
+	if d.HasChange("sequence") {
+		routeMap.Sequence = d.Get("sequence").(int)
+	}
+	routeMap.Permit = d.Get("permit").(bool)
+	if d.HasChange("match") {
+		routeMap.Match = d.Get("match").(string)
+	}
+	if d.HasChange("set") {
+		routeMap.Set = d.Get("set").(string)
+	}
+	if d.HasChange("description") {
+		routeMap.Description = d.Get("description").(string)
+	}
+	return routeMap
+}
+
+func resourceNetworkBGPRouteMap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkBGPRouteMapCreate,
+		ReadContext:   resourceNetworkBGPRouteMapRead,
+		UpdateContext: resourceNetworkBGPRouteMapUpdate,
+		DeleteContext: resourceNetworkBGPRouteMapDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"sequence": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+			"permit": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"match": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"set": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkBGPRouteMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkBGPRouteMapFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkBGPRouteMapEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPRouteMapRead(ctx, d, m)
+}
+
+func resourceNetworkBGPRouteMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkBGPRouteMapFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkBGPRouteMapEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPRouteMapRead(ctx, d, m)
+}
+
+func resourceNetworkBGPRouteMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkBGPRouteMapEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var routeMap NetworkBGPRouteMap
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &routeMap)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", routeMap)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving bgp route map data")
+	}
+
+	d.Set("vnet", routeMap.VNET)
+	d.Set("tag", routeMap.Tag)
+	d.Set("sequence", routeMap.Sequence)
+	d.Set("permit", routeMap.Permit)
+	d.Set("match", routeMap.Match)
+	d.Set("set", routeMap.Set)
+	d.Set("description", routeMap.Description)
+	return diags
+}
+
+func resourceNetworkBGPRouteMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkBGPRouteMapEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
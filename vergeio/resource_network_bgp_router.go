@@ -0,0 +1,226 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkBGPRouterEndpoint is the api endpoint representing this resource
+const NetworkBGPRouterEndpoint = "api/v4/vnet_bgp_routers"
+
+// NetworkBGPRouter is the data structure for bgp routers on a vnet in vergeos
+type NetworkBGPRouter struct {
+	VNET     int    `json:"vnet,omitempty"`
+	ASN      int    `json:"asn,omitempty"`
+	RouterID string `json:"router_id,omitempty"`
+}
+
+func newNetworkBGPRouterFromResource(d *schema.ResourceData) *NetworkBGPRouter {
+	router := &NetworkBGPRouter{}
+	if d.HasChange("vnet") {
+		router.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("asn") {
+		router.ASN = d.Get("asn").(int)
+	}
+	if d.HasChange("router_id") {
+		router.RouterID = d.Get("router_id").(string)
+	}
+	return router
+}
+
+func resourceNetworkBGPRouter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkBGPRouterCreate,
+		ReadContext:   resourceNetworkBGPRouterRead,
+		UpdateContext: resourceNetworkBGPRouterUpdate,
+		DeleteContext: resourceNetworkBGPRouterDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"asn": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateASN,
+			},
+			"router_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+		},
+	}
+}
+
+// validateASN checks that an autonomous system number lies within the 4-byte ASN range
+func validateASN(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(int)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be int", k))
+		return warnings, errors
+	}
+	if v < 1 || int64(v) > 4294967294 {
+		errors = append(errors, fmt.Errorf("expected %s to be a valid ASN between 1 and 4294967294, got %d", k, v))
+	}
+	return warnings, errors
+}
+
+func resourceNetworkBGPRouterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkBGPRouterFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPRouterRead(ctx, d, m)
+}
+
+func resourceNetworkBGPRouterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkBGPRouterFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkBGPRouterEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkBGPRouterRead(ctx, d, m)
+}
+
+func resourceNetworkBGPRouterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var router NetworkBGPRouter
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &router)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", router)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving bgp router data")
+	}
+
+	d.Set("vnet", router.VNET)
+	d.Set("asn", router.ASN)
+	d.Set("router_id", router.RouterID)
+	return diags
+}
+
+func resourceNetworkBGPRouterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkBGPRouterEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getNetworkBGPRouter retrieves a single bgp router by its key
+func getNetworkBGPRouter(c *Client, router int) (*NetworkBGPRouter, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		NetworkBGPRouterEndpoint,
+		router,
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var bgpRouter NetworkBGPRouter
+	err = json.Unmarshal(body, &bgpRouter)
+	if err != nil {
+		return nil, err
+	}
+	return &bgpRouter, nil
+}
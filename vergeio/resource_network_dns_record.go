@@ -0,0 +1,257 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkDNSRecordEndpoint is the api endpoint representing this resource
+const NetworkDNSRecordEndpoint = "api/v4/vnet_dns_zone_records"
+
+// NetworkDNSRecord is the data structure for records in a dns zone of a vnet in vergeos
+type NetworkDNSRecord struct {
+	Zone         int    `json:"zone,omitempty"`
+	Host         string `json:"host,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Value        string `json:"value,omitempty"`
+	TTL          string `json:"ttl,omitempty"`
+	MXPreference int    `json:"mx_preference,omitempty"`
+	Weight       int    `json:"weight,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+func newNetworkDNSRecordFromResource(d *schema.ResourceData) *NetworkDNSRecord {
+	record := &NetworkDNSRecord{}
+	if d.HasChange("zone") {
+		record.Zone = d.Get("zone").(int)
+	}
+	if d.HasChange("host") {
+		record.Host = d.Get("host").(string)
+	}
+	if d.HasChange("type") {
+		record.Type = d.Get("type").(string)
+	}
+	if d.HasChange("value") {
+		record.Value = d.Get("value").(string)
+	}
+	if d.HasChange("ttl") {
+		record.TTL = d.Get("ttl").(string)
+	}
+	if d.HasChange("mx_preference") {
+		record.MXPreference = d.Get("mx_preference").(int)
+	}
+	if d.HasChange("weight") {
+		record.Weight = d.Get("weight").(int)
+	}
+	if d.HasChange("port") {
+		record.Port = d.Get("port").(int)
+	}
+	if d.HasChange("description") {
+		record.Description = d.Get("description").(string)
+	}
+	return record
+}
+
+func resourceNetworkDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkDNSRecordCreate,
+		ReadContext:   resourceNetworkDNSRecordRead,
+		UpdateContext: resourceNetworkDNSRecordUpdate,
+		DeleteContext: resourceNetworkDNSRecordDelete,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"A",
+					"AAAA",
+					"CNAME",
+					"MX",
+					"TXT",
+					"SRV",
+				}, false),
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"mx_preference": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"weight": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumberOrZero,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkDNSRecordFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkDNSRecordEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = applyNetworkDNSZone(client, d.Get("zone").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkDNSRecordRead(ctx, d, m)
+}
+
+func resourceNetworkDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkDNSRecordFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkDNSRecordEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = applyNetworkDNSZone(c, d.Get("zone").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkDNSRecordRead(ctx, d, m)
+}
+
+func resourceNetworkDNSRecordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkDNSRecordEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var record NetworkDNSRecord
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &record)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", record)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving dns record data")
+	}
+
+	d.Set("zone", record.Zone)
+	d.Set("host", record.Host)
+	d.Set("type", record.Type)
+	d.Set("value", record.Value)
+	d.Set("ttl", record.TTL)
+	d.Set("mx_preference", record.MXPreference)
+	d.Set("weight", record.Weight)
+	d.Set("port", record.Port)
+	d.Set("description", record.Description)
+	return diags
+}
+
+func resourceNetworkDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkDNSRecordEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyNetworkDNSZone(client, d.Get("zone").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,379 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkDNSZoneEndpoint is the api endpoint representing this resource
+const NetworkDNSZoneEndpoint = "api/v4/vnet_dns_zones"
+
+// NetworkDNSViewEndpoint is the api endpoint representing the dns views of a vnet
+const NetworkDNSViewEndpoint = "api/v4/vnet_dns_views"
+
+// networkDNSViewName names the dns view created for a vnet that has none
+const networkDNSViewName = "terraform"
+
+// NetworkDNSZone is the data structure for dns zones served by a vnet in vergeos
+type NetworkDNSZone struct {
+	View       int    `json:"view,omitempty"`
+	VNET       int    `json:"vnet,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Nameserver string `json:"nameserver,omitempty"`
+	Email      string `json:"email,omitempty"`
+	DefaultTTL string `json:"default_ttl,omitempty"`
+	Forwarders string `json:"forwarders,omitempty"`
+}
+
+// NetworkDNSView is the data structure for dns views of a vnet in vergeos
+type NetworkDNSView struct {
+	Key  int    `json:"$key,omitempty"`
+	VNET int    `json:"vnet,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func newNetworkDNSZoneFromResource(d *schema.ResourceData) *NetworkDNSZone {
+	zone := &NetworkDNSZone{}
+	if d.HasChange("domain") {
+		zone.Domain = d.Get("domain").(string)
+	}
+	if d.HasChange("type") {
+		zone.Type = d.Get("type").(string)
+	}
+	if d.HasChange("nameserver") {
+		zone.Nameserver = d.Get("nameserver").(string)
+	}
+	if d.HasChange("email") {
+		zone.Email = d.Get("email").(string)
+	}
+	if d.HasChange("default_ttl") {
+		zone.DefaultTTL = d.Get("default_ttl").(string)
+	}
+	if d.HasChange("forwarders") {
+		zone.Forwarders = d.Get("forwarders").(string)
+	}
+	return zone
+}
+
+func resourceNetworkDNSZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkDNSZoneCreate,
+		ReadContext:   resourceNetworkDNSZoneRead,
+		UpdateContext: resourceNetworkDNSZoneUpdate,
+		DeleteContext: resourceNetworkDNSZoneDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"view": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"view_created": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the view was created for this zone, it is then removed with the zone when it holds no other zones",
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "master",
+				ValidateFunc: validation.StringInSlice([]string{
+					"master",
+					"forward",
+				}, false),
+			},
+			"nameserver": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"default_ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"forwarders": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkDNSZoneFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkDNSZoneEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "applydns")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkDNSZoneRead(ctx, d, m)
+}
+
+func resourceNetworkDNSZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	unlock := lockNetwork(d.Get("vnet").(int))
+	defer unlock()
+	view, created, err := getNetworkDNSView(c, d.Get("vnet").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("view_created", created)
+
+	resource := newNetworkDNSZoneFromResource(d)
+	resource.View = view
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkDNSZoneEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "applydns")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkDNSZoneRead(ctx, d, m)
+}
+
+func resourceNetworkDNSZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkDNSZoneEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "view,view#vnet as vnet,domain,type,nameserver,email,default_ttl,forwarders"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var zone NetworkDNSZone
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &zone)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", zone)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving dns zone data")
+	}
+
+	d.Set("vnet", zone.VNET)
+	d.Set("view", zone.View)
+	d.Set("domain", zone.Domain)
+	d.Set("type", zone.Type)
+	d.Set("nameserver", zone.Nameserver)
+	d.Set("email", zone.Email)
+	d.Set("default_ttl", zone.DefaultTTL)
+	d.Set("forwarders", zone.Forwarders)
+	return diags
+}
+
+func resourceNetworkDNSZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	unlock := lockNetwork(d.Get("vnet").(int))
+	defer unlock()
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkDNSZoneEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("view_created").(bool) {
+		err = deleteNetworkDNSView(client, d.Get("view").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	err = runNetworkAction(client, d.Get("vnet").(int), "applydns")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getNetworkDNSView returns the key of the dns view of vnet and whether it was
+// created because the vnet had none
+func getNetworkDNSView(c *Client, vnet int) (int, bool, error) {
+	request, err := c.Get(NetworkDNSViewEndpoint, &Options{
+		Fields: "$key,vnet,name",
+		Filter: fmt.Sprintf("vnet eq %d", vnet),
+		Sort:   "$key",
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	var views []NetworkDNSView
+	err = json.Unmarshal(body, &views)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(views) > 0 {
+		return views[0].Key, false, nil
+	}
+
+	bytedata, err := json.Marshal(&NetworkDNSView{
+		VNET: vnet,
+		Name: networkDNSViewName,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	request, err = c.Post(NetworkDNSViewEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return 0, false, err
+	}
+	body, err = ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	var resp VergeResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return 0, false, err
+	}
+	if resp.Error != "" {
+		return 0, false, fmt.Errorf(resp.Error)
+	}
+	view, err := strconv.Atoi(resp.Key)
+	return view, true, err
+}
+
+// deleteNetworkDNSView removes the dns view with key view when it holds no zones
+func deleteNetworkDNSView(c *Client, view int) error {
+	request, err := c.Get(NetworkDNSZoneEndpoint, &Options{
+		Fields: "$key",
+		Filter: fmt.Sprintf("view eq %d", view),
+	})
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	var zones []NetworkDNSZone
+	err = json.Unmarshal(body, &zones)
+	if err != nil {
+		return err
+	}
+	if len(zones) > 0 {
+		return nil
+	}
+	_, err = c.Delete(fmt.Sprintf("%s/%d", NetworkDNSViewEndpoint, view))
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		return nil
+	}
+	return err
+}
+
+// getNetworkDNSZoneVNET returns the key of the vnet serving zone
+func getNetworkDNSZoneVNET(c *Client, zone int) (int, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d", NetworkDNSZoneEndpoint, zone), &Options{Fields: "view#vnet as vnet"})
+	if err != nil {
+		return 0, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, err
+	}
+	var dnsZone NetworkDNSZone
+	err = json.Unmarshal(body, &dnsZone)
+	if err != nil {
+		return 0, err
+	}
+	return dnsZone.VNET, nil
+}
+
+// applyNetworkDNSZone applies the dns configuration of the vnet serving zone
+func applyNetworkDNSZone(c *Client, zone int) error {
+	vnet, err := getNetworkDNSZoneVNET(c, zone)
+	if err != nil {
+		return err
+	}
+	return runNetworkAction(c, vnet, "applydns")
+}
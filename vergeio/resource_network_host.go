@@ -0,0 +1,239 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkHostEndpoint is the api endpoint representing this resource
+const NetworkHostEndpoint = "api/v4/vnet_hosts"
+
+// NetworkHost is the data structure for DHCP host overrides on a vnet in vergeos
+type NetworkHost struct {
+	Key      int    `json:"$key,omitempty"`
+	VNET     int    `json:"vnet,omitempty"`
+	Hostname string `json:"host,omitempty"`
+	MAC      string `json:"mac,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+func newNetworkHostFromResource(d *schema.ResourceData) *NetworkHost {
+	host := &NetworkHost{
+		Type: "host",
+	}
+	if d.HasChange("vnet") {
+		host.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("hostname") {
+		host.Hostname = d.Get("hostname").(string)
+	}
+	if d.HasChange("macaddress") {
+		host.MAC = d.Get("macaddress").(string)
+	}
+	if d.HasChange("ip") {
+		host.IP = d.Get("ip").(string)
+	}
+	return host
+}
+
+func resourceNetworkHost() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkHostCreate,
+		ReadContext:   resourceNetworkHostRead,
+		UpdateContext: resourceNetworkHostUpdate,
+		DeleteContext: resourceNetworkHostDelete,
+		CustomizeDiff: resourceNetworkHostCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"macaddress": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsMACAddress,
+			},
+			"ip": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+		},
+	}
+}
+
+// resourceNetworkHostCustomizeDiff checks that ip lies inside the subnet of the vnet and outside its dynamic range
+func resourceNetworkHostCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	vnet := d.Get("vnet").(int)
+	ip := net.ParseIP(d.Get("ip").(string))
+	if vnet == 0 || ip == nil || !d.HasChanges("vnet", "ip") {
+		return nil
+	}
+	return checkNetworkHostIP(m.(*Client), vnet, ip)
+}
+
+func resourceNetworkHostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkHostFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkHostEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceNetworkHostRead(ctx, d, m)
+}
+
+func resourceNetworkHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkHostFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkHostEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+	return resourceNetworkHostRead(ctx, d, m)
+}
+
+func resourceNetworkHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkHostEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var host NetworkHost
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &host)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", host)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving network host data")
+	}
+
+	d.Set("vnet", host.VNET)
+	d.Set("hostname", host.Hostname)
+	d.Set("macaddress", host.MAC)
+	d.Set("ip", host.IP)
+	return diags
+}
+
+func resourceNetworkHostDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkHostEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getNetworkHost retrieves a single DHCP host override by its key
+func getNetworkHost(c *Client, key int) (*NetworkHost, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		NetworkHostEndpoint,
+		key,
+	), &Options{Fields: "$key,vnet,host,mac,ip,type"})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var host NetworkHost
+	err = json.Unmarshal(body, &host)
+	if err != nil {
+		return nil, err
+	}
+	return &host, nil
+}
+
+// checkNetworkHostIP checks that ip lies inside the subnet of vnet and outside its dynamic range
+func checkNetworkHostIP(c *Client, vnet int, ip net.IP) error {
+	network, err := getNetwork(c, vnet)
+	if err != nil {
+		return err
+	}
+	subnet := networkSubnet(network.CIDR, network.IPaddress)
+	if subnet != nil && !subnet.Contains(ip) {
+		return fmt.Errorf("ip %s is not within the subnet %s of vnet %d", ip, subnet.String(), vnet)
+	}
+	if network.Dynamic_DHCP && ipInRange(ip, network.DynamicIP_Start, network.DynamicIP_Stop) {
+		return fmt.Errorf("ip %s is within the dynamic range %s - %s of vnet %d", ip, network.DynamicIP_Start, network.DynamicIP_Stop, vnet)
+	}
+	return nil
+}
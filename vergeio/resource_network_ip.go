@@ -0,0 +1,268 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func newNetworkIPFromResource(d *schema.ResourceData) *NetworkAddress {
+	address := &NetworkAddress{}
+	if d.HasChange("vnet") {
+		address.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("type") {
+		address.Type = d.Get("type").(string)
+	}
+	if d.HasChange("ip") {
+		address.IP = d.Get("ip").(string)
+	}
+	if d.HasChange("macaddress") {
+		address.MAC = d.Get("macaddress").(string)
+	}
+	if d.HasChange("description") {
+		address.Description = d.Get("description").(string)
+	}
+	return address
+}
+
+func resourceNetworkIP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkIPCreate,
+		ReadContext:   resourceNetworkIPRead,
+		UpdateContext: resourceNetworkIPUpdate,
+		DeleteContext: resourceNetworkIPDelete,
+		CustomizeDiff: resourceNetworkIPCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"ipalias",
+					"virtual",
+					"ipblock",
+				}, false),
+			},
+			"ip": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsCIDR),
+				Description:  "IP address, or network in CIDR notation for ipblock",
+			},
+			"macaddress": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsMACAddress,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceNetworkIPCustomizeDiff checks that ip does not overlap an address already on the vnet
+func resourceNetworkIPCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChanges("vnet", "ip") {
+		return nil
+	}
+	return checkNetworkAddressOverlap(m.(*Client), d.Get("vnet").(int), "ip", d.Get("ip").(string), d.Id())
+}
+
+// checkNetworkAddressOverlap returns an error when ip overlaps an address on
+// vnet other than the one with key id. key names the argument holding ip.
+func checkNetworkAddressOverlap(c *Client, vnet int, key string, ip string, id string) error {
+	ipnet := networkAddressNet(ip)
+	if vnet == 0 || ipnet == nil {
+		return nil
+	}
+	addresses, err := getNetworkAddresses(c, fmt.Sprintf("vnet eq %d", vnet))
+	if err != nil {
+		return err
+	}
+	if address := findNetworkAddressOverlap(ipnet, addresses, id); address != nil {
+		return fmt.Errorf("%s %s conflicts with %s address %s on vnet %d", key, ip, address.Type, address.IP, vnet)
+	}
+	return nil
+}
+
+// findNetworkAddressOverlap returns the first of addresses, other than the one with key id, overlapping ipnet
+func findNetworkAddressOverlap(ipnet *net.IPNet, addresses []NetworkAddress, id string) *NetworkAddress {
+	for i, address := range addresses {
+		if id != "" && strconv.Itoa(address.Key) == id {
+			continue
+		}
+		existing := networkAddressNet(address.IP)
+		if existing == nil {
+			continue
+		}
+		if existing.Contains(ipnet.IP) || ipnet.Contains(existing.IP) {
+			return &addresses[i]
+		}
+	}
+	return nil
+}
+
+// networkAddressNet parses an address table ip, which may be a single address or a CIDR block
+func networkAddressNet(ip string) *net.IPNet {
+	switch {
+	case strings.Contains(ip, "/"):
+	case strings.Contains(ip, ":"):
+		ip += "/128"
+	default:
+		ip += "/32"
+	}
+	_, ipnet, err := net.ParseCIDR(ip)
+	if err != nil {
+		return nil
+	}
+	return ipnet
+}
+
+func resourceNetworkIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkIPFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkIPRead(ctx, d, m)
+}
+
+func resourceNetworkIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkIPFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkAddressEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkIPRead(ctx, d, m)
+}
+
+func resourceNetworkIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var address NetworkAddress
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &address)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", address)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving network ip data")
+	}
+
+	d.Set("vnet", address.VNET)
+	d.Set("type", address.Type)
+	d.Set("ip", address.IP)
+	d.Set("macaddress", address.MAC)
+	d.Set("description", address.Description)
+	return diags
+}
+
+func resourceNetworkIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,72 @@
+package vergeio
+
+import (
+	"testing"
+)
+
+func TestNetworkAddressNet(t *testing.T) {
+	cases := []struct {
+		ip   string
+		want string
+	}{
+		{"192.168.0.10", "192.168.0.10/32"},
+		{"192.168.0.0/24", "192.168.0.0/24"},
+		{"192.168.0.77/28", "192.168.0.64/28"},
+		{"fd00::10", "fd00::10/128"},
+		{"fd00::10/64", "fd00::/64"},
+		{"", ""},
+		{"not-an-ip", ""},
+		{"192.168.0.0/33", ""},
+	}
+	for _, tc := range cases {
+		got := networkAddressNet(tc.ip)
+		if tc.want == "" {
+			if got != nil {
+				t.Errorf("networkAddressNet(%q) = %s, want nil", tc.ip, got)
+			}
+			continue
+		}
+		if got == nil || got.String() != tc.want {
+			t.Errorf("networkAddressNet(%q) = %v, want %s", tc.ip, got, tc.want)
+		}
+	}
+}
+
+func TestFindNetworkAddressOverlap(t *testing.T) {
+	addresses := []NetworkAddress{
+		{Key: 1, Type: "static", IP: "10.0.0.5"},
+		{Key: 2, Type: "ipblock", IP: "10.0.1.0/28"},
+		{Key: 3, Type: "dhcp", IP: ""},
+		{Key: 4, Type: "static", IP: "fd00::5"},
+	}
+	cases := []struct {
+		name string
+		ip   string
+		id   string
+		want int
+	}{
+		{"free address", "10.0.0.6", "", 0},
+		{"same address", "10.0.0.5", "", 1},
+		{"address inside block", "10.0.1.7", "", 2},
+		{"block containing address", "10.0.0.0/24", "", 1},
+		{"block overlapping block", "10.0.1.8/29", "", 2},
+		{"adjacent block", "10.0.1.16/28", "", 0},
+		{"ipv6 address", "fd00::5", "", 4},
+		{"ipv6 neighbour address", "fd00::6", "", 0},
+		{"ipv6 block containing address", "fd00::/64", "", 4},
+		{"own entry is ignored", "10.0.0.5", "1", 0},
+		{"own block is ignored", "10.0.1.0/24", "2", 0},
+		{"other entries still conflict with a larger block", "10.0.0.0/16", "2", 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := findNetworkAddressOverlap(networkAddressNet(tc.ip), addresses, tc.id)
+			switch {
+			case tc.want == 0 && got != nil:
+				t.Errorf("got conflict with %s, want none", got.IP)
+			case tc.want != 0 && (got == nil || got.Key != tc.want):
+				t.Errorf("got %v, want conflict with key %d", got, tc.want)
+			}
+		})
+	}
+}
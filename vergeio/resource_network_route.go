@@ -0,0 +1,213 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newNetworkRouteFromResource builds the route rule representing a static route on a vnet
+func newNetworkRouteFromResource(d *schema.ResourceData) *NetworkRule {
+	rule := &NetworkRule{
+		Direction:       "outgoing",
+		Action:          "route",
+		Protocol:        "any",
+		SourceType:      "any",
+		DestinationType: "ip",
+		TargetType:      "ip",
+	}
+	if d.HasChange("vnet") {
+		rule.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("name") {
+		rule.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		rule.Description = d.Get("description").(string)
+	}
+	rule.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("prefix") {
+		rule.Destination = d.Get("prefix").(string)
+	}
+	if d.HasChange("gateway") {
+		rule.Target = d.Get("gateway").(string)
+	}
+	return rule
+}
+
+func resourceNetworkRoute() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkRouteCreate,
+		ReadContext:   resourceNetworkRouteRead,
+		UpdateContext: resourceNetworkRouteUpdate,
+		DeleteContext: resourceNetworkRouteDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+			"gateway": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+		},
+	}
+}
+
+func resourceNetworkRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkRouteFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkRuleEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkRouteRead(ctx, d, m)
+}
+
+func resourceNetworkRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkRouteFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkRuleEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkRouteRead(ctx, d, m)
+}
+
+func resourceNetworkRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkRuleEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var rule NetworkRule
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &rule)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", rule)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving network route data")
+	}
+
+	d.Set("vnet", rule.VNET)
+	d.Set("name", rule.Name)
+	d.Set("description", rule.Description)
+	d.Set("enabled", rule.Enabled)
+	d.Set("prefix", rule.Destination)
+	d.Set("gateway", rule.Target)
+	return diags
+}
+
+func resourceNetworkRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkRuleEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
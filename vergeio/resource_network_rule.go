@@ -0,0 +1,386 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NetworkRuleEndpoint is the api endpoint representing this resource
+const NetworkRuleEndpoint = "api/v4/vnet_rules"
+
+// NetworkRule is the data structure for firewall rules on a vnet in vergeos
+type NetworkRule struct {
+	VNET             int    `json:"vnet,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Enabled          bool   `json:"enabled"`
+	Direction        string `json:"direction,omitempty"`
+	Action           string `json:"action,omitempty"`
+	Protocol         string `json:"protocol,omitempty"`
+	Interface        string `json:"interface,omitempty"`
+	Pin              string `json:"pin,omitempty"`
+	Order            int    `json:"orderid,omitempty"`
+	SourceType       string `json:"source_ip_type,omitempty"`
+	Source           string `json:"source_ip,omitempty"`
+	SourcePorts      string `json:"source_ports,omitempty"`
+	DestinationType  string `json:"destination_ip_type,omitempty"`
+	Destination      string `json:"destination_ip,omitempty"`
+	DestinationPorts string `json:"destination_ports,omitempty"`
+	TargetType       string `json:"target_ip_type,omitempty"`
+	Target           string `json:"target_ip,omitempty"`
+	TargetPorts      string `json:"target_ports,omitempty"`
+}
+
+var networkRuleIPTypes = []string{
+	"any",
+	"ip",
+	"alias",
+	"dns",
+	"my_ip",
+	"my_network",
+	"my_router_ip",
+	"default",
+	"wan_ip",
+}
+
+func newNetworkRuleFromResource(d *schema.ResourceData) *NetworkRule {
+	rule := &NetworkRule{
+		Enabled: d.Get("enabled").(bool),
+	}
+	if d.HasChange("vnet") {
+		rule.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("name") {
+		rule.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		rule.Description = d.Get("description").(string)
+	}
+	if d.HasChange("direction") {
+		rule.Direction = d.Get("direction").(string)
+	}
+	if d.HasChange("action") {
+		rule.Action = d.Get("action").(string)
+	}
+	if d.HasChange("protocol") {
+		rule.Protocol = d.Get("protocol").(string)
+	}
+	if d.HasChange("interface") {
+		rule.Interface = d.Get("interface").(string)
+	}
+	if d.HasChange("pin") {
+		rule.Pin = d.Get("pin").(string)
+	}
+	if d.HasChange("order") {
+		rule.Order = d.Get("order").(int)
+	}
+	if d.HasChange("source_type") {
+		rule.SourceType = d.Get("source_type").(string)
+	}
+	if d.HasChange("source") {
+		rule.Source = d.Get("source").(string)
+	}
+	if d.HasChange("source_ports") {
+		rule.SourcePorts = d.Get("source_ports").(string)
+	}
+	if d.HasChange("destination_type") {
+		rule.DestinationType = d.Get("destination_type").(string)
+	}
+	if d.HasChange("destination") {
+		rule.Destination = d.Get("destination").(string)
+	}
+	if d.HasChange("destination_ports") {
+		rule.DestinationPorts = d.Get("destination_ports").(string)
+	}
+	if d.HasChange("target_type") {
+		rule.TargetType = d.Get("target_type").(string)
+	}
+	if d.HasChange("target") {
+		rule.Target = d.Get("target").(string)
+	}
+	if d.HasChange("target_ports") {
+		rule.TargetPorts = d.Get("target_ports").(string)
+	}
+	return rule
+}
+
+func resourceNetworkRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkRuleCreate,
+		ReadContext:   resourceNetworkRuleRead,
+		UpdateContext: resourceNetworkRuleUpdate,
+		DeleteContext: resourceNetworkRuleDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"direction": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"incoming",
+					"outgoing",
+				}, false),
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"accept",
+					"reject",
+					"drop",
+					"translate",
+					"route",
+				}, false),
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"any",
+					"tcp",
+					"udp",
+					"tcpudp",
+					"icmp",
+				}, false),
+			},
+			"interface": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"pin": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"no",
+					"top",
+					"bottom",
+				}, false),
+			},
+			"order": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"source_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(networkRuleIPTypes, false),
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"source_ports": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"destination_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(networkRuleIPTypes, false),
+			},
+			"destination": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"destination_ports": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"target_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(networkRuleIPTypes, false),
+			},
+			"target": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"target_ports": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newNetworkRuleFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkRuleEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkRuleRead(ctx, d, m)
+}
+
+func resourceNetworkRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newNetworkRuleFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkRuleEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceNetworkRuleRead(ctx, d, m)
+}
+
+func resourceNetworkRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkRuleEndpoint,
+		url.PathEscape(d.Id()),
+	), nil)
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var rule NetworkRule
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &rule)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", rule)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving network rule data")
+	}
+
+	d.Set("vnet", rule.VNET)
+	d.Set("name", rule.Name)
+	d.Set("description", rule.Description)
+	d.Set("enabled", rule.Enabled)
+	d.Set("direction", rule.Direction)
+	d.Set("action", rule.Action)
+	d.Set("protocol", rule.Protocol)
+	d.Set("interface", rule.Interface)
+	d.Set("pin", rule.Pin)
+	d.Set("order", rule.Order)
+	d.Set("source_type", rule.SourceType)
+	d.Set("source", rule.Source)
+	d.Set("source_ports", rule.SourcePorts)
+	d.Set("destination_type", rule.DestinationType)
+	d.Set("destination", rule.Destination)
+	d.Set("destination_ports", rule.DestinationPorts)
+	d.Set("target_type", rule.TargetType)
+	d.Set("target", rule.Target)
+	d.Set("target_ports", rule.TargetPorts)
+	return diags
+}
+
+func resourceNetworkRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkRuleEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
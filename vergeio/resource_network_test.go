@@ -0,0 +1,62 @@
+package vergeio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNextNetworkAction(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   NetworkStatus
+		enabled  bool
+		powerOff bool
+		want     string
+	}{
+		{"power on", NetworkStatus{}, true, false, "poweron"},
+		{"power off", NetworkStatus{Running: true}, false, true, "poweroff"},
+		{"left running when enabled is unchanged", NetworkStatus{Running: true}, false, false, ""},
+		{"stopped vnet needs nothing", NetworkStatus{NeedsApply: true}, false, false, ""},
+		{"restart before apply", NetworkStatus{Running: true, NeedsRestart: true, NeedsApply: true}, true, false, "restart"},
+		{"apply before dns", NetworkStatus{Running: true, NeedsApply: true, NeedsDNS: true}, true, false, "apply"},
+		{"dns", NetworkStatus{Running: true, NeedsDNS: true}, true, false, "applydns"},
+		{"nothing pending", NetworkStatus{Running: true}, true, false, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, done := nextNetworkAction(&tc.status, tc.enabled, tc.powerOff)
+			if got != tc.want {
+				t.Fatalf("got action %q, want %q", got, tc.want)
+			}
+			if got != "" && done(&tc.status) {
+				t.Errorf("action %q is already done before it ran", got)
+			}
+		})
+	}
+}
+
+func TestNetworkCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{"range inside the cidr", map[string]interface{}{"cidr": "10.0.0.0/24", "ipaddress": "10.0.0.1", "dhcp_start": "10.0.0.100", "dhcp_stop": "10.0.0.200"}, false},
+		{"no cidr", map[string]interface{}{"dhcp_start": "10.0.0.100", "dhcp_stop": "10.0.0.200"}, false},
+		{"start outside the cidr", map[string]interface{}{"cidr": "10.0.0.0/24", "dhcp_start": "10.0.1.100", "dhcp_stop": "10.0.0.200"}, true},
+		{"stop outside the cidr", map[string]interface{}{"cidr": "10.0.0.0/24", "dhcp_start": "10.0.0.100", "dhcp_stop": "10.0.1.200"}, true},
+		{"router outside the cidr", map[string]interface{}{"cidr": "10.0.0.0/24", "ipaddress": "10.0.1.1"}, true},
+		{"start after stop", map[string]interface{}{"cidr": "10.0.0.0/24", "dhcp_start": "10.0.0.200", "dhcp_stop": "10.0.0.100"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config["name"] = "test"
+			_, err := resourceNetwork().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.config), nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,6 +20,9 @@ import (
 // NICEndpoint is the api endpoint representing this resource
 const NICEndpoint = "api/v4/machine_nics"
 
+// NetworkAddressEndpoint is the api endpoint representing the address table of a vnet
+const NetworkAddressEndpoint = "api/v4/vnet_addresses"
+
 // NIC is the data structure for virtual machines in vergeos
 type NIC struct {
 	Machine     int    `json:"machine,omitempty"`
@@ -31,8 +37,25 @@ type NIC struct {
 	VNET        int    `json:"vnet,omitempty"`
 	MAC         string `json:"macaddress,omitempty"`
 	Asset       string `json:"asset,omitempty"`
+	Queues      int    `json:"queues"`
+	LinkState   string `json:"link_state,omitempty"`
+}
+
+// NetworkAddress is the data structure for entries in the address table of a vnet
+type NetworkAddress struct {
+	Key         int    `json:"$key,omitempty"`
+	VNET        int    `json:"vnet,omitempty"`
+	Type        string `json:"type,omitempty"`
+	IP          string `json:"ip,omitempty"`
+	MAC         string `json:"mac,omitempty"`
+	Description string `json:"description,omitempty"`
+	Owner       string `json:"owner,omitempty"`
 }
 
+// nicHardwareFields are the NIC arguments a running guest only sees after the NIC is plugged in again.
+// link_state is not among them, it is applied to a running NIC directly.
+var nicHardwareFields = []string{"interface", "driver", "model", "vendor", "macaddress", "queues"}
+
 func newNICFromResource(d *schema.ResourceData) *NIC {
 	nic := &NIC{}
 	if d.HasChange("machine") {
@@ -51,17 +74,15 @@ func newNICFromResource(d *schema.ResourceData) *NIC {
 		nic.Driver = d.Get("driver").(string)
 	}
 	if d.HasChange("model") {
-		nic.Model = d.Get("disksize").(string)
+		nic.Model = d.Get("model").(string)
 	}
 	if d.HasChange("vendor") {
 		nic.Vendor = d.Get("vendor").(string)
 	}
 	if d.HasChange("port") {
-		nic.Port = d.Get("readonly").(int)
-	}
-	if d.HasChange("enabled") {
-		nic.Enabled = d.Get("enabled").(bool)
+		nic.Port = d.Get("port").(int)
 	}
+	nic.Enabled = d.Get("enabled").(bool)
 	if d.HasChange("vnet") {
 		nic.VNET = d.Get("vnet").(int)
 	}
@@ -71,6 +92,10 @@ func newNICFromResource(d *schema.ResourceData) *NIC {
 	if d.HasChange("asset") {
 		nic.Asset = d.Get("asset").(string)
 	}
+	nic.Queues = d.Get("queues").(int)
+	if d.HasChange("link_state") {
+		nic.LinkState = d.Get("link_state").(string)
+	}
 	return nic
 }
 
@@ -129,7 +154,7 @@ func resourceNIC() *schema.Resource {
 			"enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				Computed: true,
+				Default:  true,
 			},
 			"vnet": {
 				Type:     schema.TypeInt,
@@ -146,6 +171,47 @@ func resourceNIC() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"queues": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 64),
+				Description:  "Number of virtio queues, 0 uses the default",
+			},
+			"link_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"connected",
+					"disconnected",
+				}, false),
+			},
+			"hotplug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Hot add, replug and remove the NIC when the virtual machine is running",
+			},
+			"ip_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "IP address reserved for the NIC's MAC address on the attached vNET with a DHCP host override",
+			},
+			"host_override": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Key of the DHCP host override created for ip_address",
+			},
+			"ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "IP addresses assigned to the NIC's MAC address in the vNET address table",
+			},
 		},
 	}
 }
@@ -153,29 +219,94 @@ func resourceNIC() *schema.Resource {
 func resourceNICUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	client := m.(*Client)
-	resource := newNICFromResource(d)
-	bytedata, err := json.Marshal(resource)
-	log.Printf("[DEBUG] resource data %s", string(bytedata))
-	if err != nil {
-		return diag.FromErr(err)
+	var diags diag.Diagnostics
+
+	// The guest only picks up hardware changes when the NIC is plugged in again
+	var vm *VMState
+	var changed []string
+	for _, field := range nicHardwareFields {
+		if d.HasChange(field) {
+			changed = append(changed, field)
+		}
+	}
+	if len(changed) > 0 {
+		state, err := getVMState(client, d.Get("machine").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if state.Running && d.Get("hotplug").(bool) && state.AllowHotplug {
+			vm = state
+		} else if state.Running {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "NIC changes take effect when the virtual machine restarts",
+				Detail:   fmt.Sprintf("machine %d is running; set hotplug and allow_hotplug on the virtual machine to apply %s without a restart", d.Get("machine").(int), strings.Join(changed, ", ")),
+			})
+		}
+	}
+	if vm != nil {
+		err := runVMAction(client, vm.Key, "hotunplugnic", map[string]interface{}{"nic": d.Id()})
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
-	req, err := client.Put(fmt.Sprintf("%s/%s",
-		NICEndpoint,
-		d.Id(),
-	), bytes.NewBuffer(bytedata))
 
+	err := putNIC(client, d)
+	// Plug the NIC back in even when the update failed, the guest would lose it otherwise
+	if vm != nil {
+		plugErr := runVMAction(client, vm.Key, "hotplugnic", map[string]interface{}{"nic": d.Id()})
+		if err == nil {
+			err = plugErr
+		} else if plugErr != nil {
+			log.Printf("[WARN] could not plug nic %s back in: %s", d.Id(), plugErr)
+		}
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if req.StatusCode != 200 {
-		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	if d.HasChanges("ip_address", "vnet", "macaddress", "name") {
+		override := d.Get("host_override").(int)
+		// Host overrides cannot move between vnets, replace it instead
+		if d.HasChange("vnet") && override != 0 {
+			err = deleteNICHostOverride(client, override)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			override = 0
+		}
+		override, err = setNICHostOverride(client,
+			override,
+			d.Get("vnet").(int),
+			d.Get("macaddress").(string),
+			d.Get("ip_address").(string),
+			d.Get("name").(string),
+		)
+		d.Set("host_override", override)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
-	return resourceNICRead(ctx, d, m)
+	return append(diags, resourceNICRead(ctx, d, m)...)
 }
 
 func resourceNICCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+
+	var vm *VMState
+	if d.Get("hotplug").(bool) {
+		state, err := getVMState(c, d.Get("machine").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if state.Running && !state.AllowHotplug {
+			return diag.Errorf("machine %d is running and does not allow hotplug", d.Get("machine").(int))
+		}
+		if state.Running {
+			vm = state
+		}
+	}
+
 	resource := newNICFromResource(d)
 	bytedata, err := json.Marshal(&resource)
 	if err != nil {
@@ -200,6 +331,25 @@ func resourceNICCreate(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.Errorf(resp.Error)
 	}
 	d.SetId(string(resp.Key))
+
+	if vm != nil {
+		err = runVMAction(c, vm.Key, "hotplugnic", map[string]interface{}{"nic": resp.Key})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if ip := d.Get("ip_address").(string); ip != "" {
+		nic, err := getNIC(c, d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		override, err := setNICHostOverride(c, 0, nic.VNET, nic.MAC, ip, nic.Name)
+		d.Set("host_override", override)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceNICRead(ctx, d, m)
 }
 
@@ -254,6 +404,35 @@ func resourceNICRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	d.Set("macaddress", nic.MAC)
 	d.Set("asset", nic.Asset)
 	d.Set("enabled", nic.Enabled)
+	d.Set("queues", nic.Queues)
+	d.Set("link_state", nic.LinkState)
+
+	ipAddress := ""
+	override := d.Get("host_override").(int)
+	if override != 0 {
+		host, err := getNetworkHost(c, override)
+		if e, ok := err.(Error); ok && e.StatusCode == 404 {
+			override = 0
+		} else if err != nil {
+			return diag.FromErr(err)
+		} else {
+			ipAddress = host.IP
+		}
+	}
+	d.Set("ip_address", ipAddress)
+	d.Set("host_override", override)
+
+	ipAddresses := []string{}
+	if nic.VNET != 0 && nic.MAC != "" {
+		addresses, err := getNetworkAddresses(c, fmt.Sprintf("vnet eq %d and mac eq '%s'", nic.VNET, nic.MAC))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, address := range addresses {
+			ipAddresses = append(ipAddresses, address.IP)
+		}
+	}
+	d.Set("ip_addresses", ipAddresses)
 
 	return diags
 }
@@ -261,6 +440,24 @@ func resourceNICRead(ctx context.Context, d *schema.ResourceData, m interface{})
 func resourceNICDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*Client)
+	if d.Get("hotplug").(bool) {
+		state, err := getVMState(client, d.Get("machine").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if state.Running {
+			err = runVMAction(client, state.Key, "hotunplugnic", map[string]interface{}{"nic": d.Id()})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if override := d.Get("host_override").(int); override != 0 {
+		err := deleteNICHostOverride(client, override)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	_, err := client.Delete(fmt.Sprintf("%s/%s",
 		NICEndpoint,
 		d.Id(),
@@ -270,3 +467,136 @@ func resourceNICDelete(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 	return diags
 }
+
+// getNIC retrieves a single nic by its key
+func getNIC(c *Client, id string) (*NIC, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NICEndpoint,
+		url.PathEscape(id),
+	), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var nic NIC
+	err = json.Unmarshal(body, &nic)
+	if err != nil {
+		return nil, err
+	}
+	return &nic, nil
+}
+
+// getNetworkAddresses retrieves the vnet address table entries matching filter
+func getNetworkAddresses(c *Client, filter string) ([]NetworkAddress, error) {
+	request, err := c.Get(NetworkAddressEndpoint, &Options{
+		Fields: "$key,vnet,type,ip,mac,description,owner",
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []NetworkAddress
+	err = json.Unmarshal(body, &addresses)
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// putNIC sends the arguments of d to the NIC
+func putNIC(c *Client, d *schema.ResourceData) error {
+	resource := newNICFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return err
+	}
+	req, err := c.Put(fmt.Sprintf("%s/%s",
+		NICEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+	if err != nil {
+		return err
+	}
+	if req.StatusCode != 200 {
+		return fmt.Errorf("Error updating resource: %d", req.StatusCode)
+	}
+	return nil
+}
+
+// setNICHostOverride creates or updates the DHCP host override with key override
+// reserving ip for mac on vnet and returns its key. An empty ip removes it.
+func setNICHostOverride(c *Client, override int, vnet int, mac string, ip string, hostname string) (int, error) {
+	if ip == "" {
+		if override == 0 {
+			return 0, nil
+		}
+		err := deleteNICHostOverride(c, override)
+		if err != nil {
+			return override, err
+		}
+		return 0, nil
+	}
+	if vnet == 0 || mac == "" {
+		return override, fmt.Errorf("ip_address needs the NIC to be attached to a vnet and have a MAC address")
+	}
+	address := net.ParseIP(ip)
+	if address == nil {
+		return override, fmt.Errorf("ip_address %q is not a valid IP address", ip)
+	}
+	err := checkNetworkHostIP(c, vnet, address)
+	if err != nil {
+		return override, err
+	}
+
+	host := NetworkHost{
+		VNET:     vnet,
+		Hostname: hostname,
+		MAC:      mac,
+		IP:       ip,
+		Type:     "host",
+	}
+	bytedata, err := json.Marshal(&host)
+	if err != nil {
+		return override, err
+	}
+	log.Printf("[DEBUG] host override data %s", string(bytedata))
+	if override != 0 {
+		_, err = c.Put(fmt.Sprintf("%s/%d", NetworkHostEndpoint, override), bytes.NewBuffer(bytedata))
+		return override, err
+	}
+
+	request, err := c.Post(NetworkHostEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return 0, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, err
+	}
+	var resp VergeResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Error != "" {
+		return 0, fmt.Errorf(resp.Error)
+	}
+	return strconv.Atoi(resp.Key)
+}
+
+// deleteNICHostOverride removes the DHCP host override with key override, ignoring one that is already gone
+func deleteNICHostOverride(c *Client, override int) error {
+	_, err := c.Delete(fmt.Sprintf("%s/%d", NetworkHostEndpoint, override))
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		return nil
+	}
+	return err
+}
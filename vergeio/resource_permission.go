@@ -0,0 +1,263 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// PermissionEndpoint is the api endpoint representing this resource
+const PermissionEndpoint = "api/v4/permissions"
+
+// Permission is the data structure for permissions granted to a user or group in vergeos
+type Permission struct {
+	Key      int    `json:"$key,omitempty"`
+	Identity int    `json:"identity,omitempty"`
+	Table    string `json:"table,omitempty"`
+	Row      int    `json:"row"`
+	List     bool   `json:"list"`
+	Read     bool   `json:"read"`
+	Create   bool   `json:"create"`
+	Modify   bool   `json:"modify"`
+	Delete   bool   `json:"delete"`
+}
+
+func newPermissionFromResource(d *schema.ResourceData) *Permission {
+	permission := &Permission{}
+	if d.HasChange("identity") {
+		permission.Identity = d.Get("identity").(int)
+	}
+	if d.HasChange("table") {
+		permission.Table = d.Get("table").(string)
+	}
+	permission.Row = d.Get("row").(int)
+	permission.List = d.Get("list").(bool)
+	permission.Read = d.Get("read").(bool)
+	permission.Create = d.Get("create").(bool)
+	permission.Modify = d.Get("modify").(bool)
+	permission.Delete = d.Get("delete").(bool)
+	return permission
+}
+
+func resourcePermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePermissionCreate,
+		ReadContext:   resourcePermissionRead,
+		UpdateContext: resourcePermissionUpdate,
+		DeleteContext: resourcePermissionDelete,
+		Schema: map[string]*schema.Schema{
+			"identity": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identity key of the user or group being granted the permission",
+			},
+			"table": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Table the permission applies to, e.g. vms, vnets or tenants",
+			},
+			"row": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     0,
+				Description: "Key of the row the permission applies to, 0 grants it on the whole table",
+			},
+			"list": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"read": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"modify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourcePermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newPermissionFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		PermissionEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourcePermissionRead(ctx, d, m)
+}
+
+func resourcePermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newPermissionFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(PermissionEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+	return resourcePermissionRead(ctx, d, m)
+}
+
+func resourcePermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		PermissionEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "$key,identity,table,row,list,read,create,modify,delete"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var permission Permission
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &permission)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", permission)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving permission data")
+	}
+
+	// Other entries for the same identity and target are only reported. They
+	// may be managed by another resource, so they are never merged or removed.
+	external, err := getExternalPermissions(c, &permission)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, other := range external {
+		log.Printf("[DEBUG] identity %d has another permission on %s/%d not managed by this resource: %#v",
+			permission.Identity, permission.Table, permission.Row, other)
+	}
+
+	d.Set("identity", permission.Identity)
+	d.Set("table", permission.Table)
+	d.Set("row", permission.Row)
+	d.Set("list", permission.List)
+	d.Set("read", permission.Read)
+	d.Set("create", permission.Create)
+	d.Set("modify", permission.Modify)
+	d.Set("delete", permission.Delete)
+	return diags
+}
+
+func resourcePermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		PermissionEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getExternalPermissions returns the other permissions granted to the identity
+// of permission on the same table and row
+func getExternalPermissions(c *Client, permission *Permission) ([]Permission, error) {
+	request, err := c.Get(PermissionEndpoint, &Options{
+		Fields: "$key,identity,table,row,list,read,create,modify,delete",
+		Filter: fmt.Sprintf("identity eq %d and table eq '%s' and row eq %d",
+			permission.Identity,
+			permission.Table,
+			permission.Row,
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var permissions []Permission
+	err = json.Unmarshal(body, &permissions)
+	if err != nil {
+		return nil, err
+	}
+	var external []Permission
+	for _, other := range permissions {
+		if other.Key != permission.Key {
+			external = append(external, other)
+		}
+	}
+	return external, nil
+}
@@ -0,0 +1,403 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// newPortForwardRulesFromResource builds the translate and accept rules making up a port forward
+func newPortForwardRulesFromResource(d *schema.ResourceData, internalIP string) (*NetworkRule, *NetworkRule) {
+	destinationType := "my_ip"
+	if d.Get("external_ip").(string) != "" {
+		destinationType = "ip"
+	}
+	internalPort := d.Get("internal_port").(string)
+	if internalPort == "" {
+		internalPort = d.Get("external_port").(string)
+	}
+
+	translate := &NetworkRule{
+		VNET:             d.Get("network").(int),
+		Name:             fmt.Sprintf("%s (translate)", d.Get("name").(string)),
+		Description:      d.Get("description").(string),
+		Enabled:          d.Get("enabled").(bool),
+		Direction:        "incoming",
+		Action:           "translate",
+		Protocol:         d.Get("protocol").(string),
+		SourceType:       "any",
+		DestinationType:  destinationType,
+		Destination:      d.Get("external_ip").(string),
+		DestinationPorts: d.Get("external_port").(string),
+		TargetType:       "ip",
+		Target:           internalIP,
+		TargetPorts:      internalPort,
+	}
+	accept := &NetworkRule{
+		VNET:             d.Get("network").(int),
+		Name:             fmt.Sprintf("%s (accept)", d.Get("name").(string)),
+		Description:      d.Get("description").(string),
+		Enabled:          d.Get("enabled").(bool),
+		Direction:        "incoming",
+		Action:           "accept",
+		Protocol:         d.Get("protocol").(string),
+		SourceType:       "any",
+		DestinationType:  destinationType,
+		Destination:      d.Get("external_ip").(string),
+		DestinationPorts: d.Get("external_port").(string),
+	}
+	return translate, accept
+}
+
+func resourcePortForward() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePortForwardCreate,
+		ReadContext:   resourcePortForwardRead,
+		UpdateContext: resourcePortForwardUpdate,
+		DeleteContext: resourcePortForwardDelete,
+		CustomizeDiff: resourcePortForwardCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"network": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the external vnet the port is published on",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tcp",
+				ValidateFunc: validation.StringInSlice([]string{
+					"tcp",
+					"udp",
+					"tcpudp",
+				}, false),
+			},
+			"external_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "External address to publish on, defaults to the network's own IP",
+			},
+			"external_port": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"internal_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				ExactlyOneOf: []string{"internal_ip", "internal_machine"},
+			},
+			"internal_machine": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"internal_ip", "internal_machine"},
+				Description:  "Machine key of the virtual machine to forward to, its first NIC address is used",
+			},
+			"internal_machine_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current address of internal_machine, a change is forwarded to on the next apply",
+			},
+			"internal_port": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Internal port, defaults to external_port",
+			},
+			"translate_rule": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"accept_rule": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePortForwardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	internalIP, err := getPortForwardInternalIP(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	translate, accept := newPortForwardRulesFromResource(d, internalIP)
+
+	err = putNetworkRule(client, d.Get("translate_rule").(int), translate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = putNetworkRule(client, d.Get("accept_rule").(int), accept)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("network").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePortForwardRead(ctx, d, m)
+}
+
+func resourcePortForwardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	internalIP, err := getPortForwardInternalIP(c, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	translate, accept := newPortForwardRulesFromResource(d, internalIP)
+
+	translateRule, err := postNetworkRule(c, translate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Track the translate rule right away so a failure below never orphans it
+	d.SetId(strconv.Itoa(translateRule))
+	d.Set("translate_rule", translateRule)
+
+	acceptRule, err := postNetworkRule(c, accept)
+	if err != nil {
+		if _, delErr := c.Delete(fmt.Sprintf("%s/%d", NetworkRuleEndpoint, translateRule)); delErr == nil {
+			d.SetId("")
+		}
+		return diag.FromErr(err)
+	}
+	d.Set("accept_rule", acceptRule)
+
+	err = runNetworkAction(c, d.Get("network").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePortForwardRead(ctx, d, m)
+}
+
+func resourcePortForwardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	var rules []NetworkRule
+	for _, key := range []int{d.Get("translate_rule").(int), d.Get("accept_rule").(int)} {
+		// The accept rule is missing when create failed half way
+		if key == 0 {
+			continue
+		}
+		request, err := c.Get(fmt.Sprintf("%s/%d",
+			NetworkRuleEndpoint,
+			key,
+		), nil)
+		if err != nil {
+			if apiErr, ok := err.(Error); ok && apiErr.StatusCode == 404 {
+				log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+				d.SetId("")
+				return diags
+			}
+			return diag.FromErr(err)
+		}
+
+		body, readerr := ioutil.ReadAll(request.Body)
+		if readerr != nil {
+			return diag.FromErr(readerr)
+		}
+		var rule NetworkRule
+		decodeerr := json.Unmarshal(body, &rule)
+		if decodeerr != nil {
+			return diag.FromErr(decodeerr)
+		}
+		log.Printf("[DEBUG] params %#v", rule)
+		rules = append(rules, rule)
+	}
+
+	translate := rules[0]
+	if len(rules) > 1 {
+		translate = mergePortForwardRules(NetworkRule{
+			Protocol:         d.Get("protocol").(string),
+			Destination:      d.Get("external_ip").(string),
+			DestinationPorts: d.Get("external_port").(string),
+		}, translate, rules[1])
+	}
+	if machine := d.Get("internal_machine").(int); machine != 0 {
+		internalIP, err := getMachineIP(c, machine)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("internal_machine_ip", internalIP)
+	}
+
+	d.Set("network", translate.VNET)
+	d.Set("name", strings.TrimSuffix(translate.Name, " (translate)"))
+	d.Set("description", translate.Description)
+	d.Set("enabled", translate.Enabled)
+	d.Set("protocol", translate.Protocol)
+	d.Set("external_ip", translate.Destination)
+	d.Set("external_port", translate.DestinationPorts)
+	d.Set("internal_ip", translate.Target)
+	if d.Get("internal_port").(string) != "" || translate.TargetPorts != translate.DestinationPorts {
+		d.Set("internal_port", translate.TargetPorts)
+	}
+	return diags
+}
+
+func resourcePortForwardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	for _, key := range []int{d.Get("accept_rule").(int), d.Get("translate_rule").(int)} {
+		_, err := client.Delete(fmt.Sprintf("%s/%d",
+			NetworkRuleEndpoint,
+			key,
+		))
+		if err != nil {
+			if apiErr, ok := err.(Error); !ok || apiErr.StatusCode != 404 {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	err := runNetworkAction(client, d.Get("network").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourcePortForwardCustomizeDiff plans an update when the address of internal_machine
+// changed. The address is refreshed by Read, it is only looked up here for a new machine.
+func resourcePortForwardCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	machine := d.Get("internal_machine").(int)
+	if d.Id() == "" || machine == 0 || !d.NewValueKnown("internal_machine") {
+		return nil
+	}
+	internalIP := d.Get("internal_machine_ip").(string)
+	if d.HasChange("internal_machine") {
+		var err error
+		internalIP, err = getMachineIP(m.(*Client), machine)
+		if err != nil {
+			return err
+		}
+		err = d.SetNew("internal_machine_ip", internalIP)
+		if err != nil {
+			return err
+		}
+	}
+	if internalIP != "" && internalIP != d.Get("internal_ip").(string) {
+		return d.SetNew("internal_ip", internalIP)
+	}
+	return nil
+}
+
+// mergePortForwardRules returns translate with the differences of the accept rule
+// from the values in state folded in, so changes made to either rule outside of
+// terraform show up as drift. An accept rule that no longer accepts incoming
+// traffic disables the port forward.
+func mergePortForwardRules(state NetworkRule, translate NetworkRule, accept NetworkRule) NetworkRule {
+	drifted := func(state, translate, accept string) string {
+		if translate != state {
+			return translate
+		}
+		return accept
+	}
+	translate.Protocol = drifted(state.Protocol, translate.Protocol, accept.Protocol)
+	translate.Destination = drifted(state.Destination, translate.Destination, accept.Destination)
+	translate.DestinationPorts = drifted(state.DestinationPorts, translate.DestinationPorts, accept.DestinationPorts)
+	translate.Enabled = translate.Enabled && accept.Enabled && accept.Action == "accept" && accept.Direction == "incoming"
+	return translate
+}
+
+// getPortForwardInternalIP returns the internal address traffic is forwarded to,
+// resolving it from the first addressed NIC of internal_machine when set
+func getPortForwardInternalIP(c *Client, d *schema.ResourceData) (string, error) {
+	machine := d.Get("internal_machine").(int)
+	if machine == 0 {
+		return d.Get("internal_ip").(string), nil
+	}
+	return getMachineIP(c, machine)
+}
+
+// getMachineIP returns the address of the first addressed NIC of machine
+func getMachineIP(c *Client, machine int) (string, error) {
+	request, err := c.Get(NICEndpoint, &Options{
+		Fields: "$key,machine,name,ipaddress",
+		Filter: fmt.Sprintf("machine eq %d", machine),
+		Sort:   "$key",
+	})
+	if err != nil {
+		return "", err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return "", err
+	}
+	var nics []NICs
+	err = json.Unmarshal(body, &nics)
+	if err != nil {
+		return "", err
+	}
+	for _, nic := range nics {
+		if nic.IPaddress != "" {
+			return nic.IPaddress, nil
+		}
+	}
+	return "", fmt.Errorf("machine %d has no NIC with an IP address", machine)
+}
+
+// postNetworkRule creates rule and returns its key
+func postNetworkRule(c *Client, rule *NetworkRule) (int, error) {
+	bytedata, err := json.Marshal(rule)
+	if err != nil {
+		return 0, err
+	}
+	request, err := c.Post(NetworkRuleEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return 0, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, err
+	}
+	var resp VergeResponse
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Error != "" {
+		return 0, fmt.Errorf(resp.Error)
+	}
+	return strconv.Atoi(resp.Key)
+}
+
+// putNetworkRule updates the rule with key
+func putNetworkRule(c *Client, key int, rule *NetworkRule) error {
+	bytedata, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	_, err = c.Put(fmt.Sprintf("%s/%d", NetworkRuleEndpoint, key), bytes.NewBuffer(bytedata))
+	return err
+}
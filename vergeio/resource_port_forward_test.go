@@ -0,0 +1,77 @@
+package vergeio
+
+import (
+	"testing"
+)
+
+func TestMergePortForwardRules(t *testing.T) {
+	state := NetworkRule{Protocol: "tcp", DestinationPorts: "443"}
+	translate := NetworkRule{Enabled: true, Action: "translate", Direction: "incoming", Protocol: "tcp", DestinationPorts: "443", Target: "10.0.0.5"}
+	accept := NetworkRule{Enabled: true, Action: "accept", Direction: "incoming", Protocol: "tcp", DestinationPorts: "443"}
+	cases := []struct {
+		name    string
+		modify  func(translate, accept *NetworkRule)
+		want    NetworkRule
+		enabled bool
+	}{
+		{
+			name:    "in sync",
+			modify:  func(translate, accept *NetworkRule) {},
+			want:    NetworkRule{Protocol: "tcp", DestinationPorts: "443"},
+			enabled: true,
+		},
+		{
+			name:    "accept protocol changed",
+			modify:  func(translate, accept *NetworkRule) { accept.Protocol = "udp" },
+			want:    NetworkRule{Protocol: "udp", DestinationPorts: "443"},
+			enabled: true,
+		},
+		{
+			name:    "accept ports changed",
+			modify:  func(translate, accept *NetworkRule) { accept.DestinationPorts = "8443" },
+			want:    NetworkRule{Protocol: "tcp", DestinationPorts: "8443"},
+			enabled: true,
+		},
+		{
+			name:    "accept address changed",
+			modify:  func(translate, accept *NetworkRule) { accept.Destination = "203.0.113.7" },
+			want:    NetworkRule{Protocol: "tcp", Destination: "203.0.113.7", DestinationPorts: "443"},
+			enabled: true,
+		},
+		{
+			name: "translate drift wins",
+			modify: func(translate, accept *NetworkRule) {
+				translate.DestinationPorts = "80"
+				accept.DestinationPorts = "8443"
+			},
+			want:    NetworkRule{Protocol: "tcp", DestinationPorts: "80"},
+			enabled: true,
+		},
+		{
+			name:   "accept disabled",
+			modify: func(translate, accept *NetworkRule) { accept.Enabled = false },
+			want:   NetworkRule{Protocol: "tcp", DestinationPorts: "443"},
+		},
+		{
+			name:   "accept turned into drop",
+			modify: func(translate, accept *NetworkRule) { accept.Action = "drop" },
+			want:   NetworkRule{Protocol: "tcp", DestinationPorts: "443"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			translate, accept := translate, accept
+			tc.modify(&translate, &accept)
+			got := mergePortForwardRules(state, translate, accept)
+			if got.Protocol != tc.want.Protocol || got.Destination != tc.want.Destination || got.DestinationPorts != tc.want.DestinationPorts {
+				t.Errorf("got %s %q %s, want %s %q %s", got.Protocol, got.Destination, got.DestinationPorts, tc.want.Protocol, tc.want.Destination, tc.want.DestinationPorts)
+			}
+			if got.Enabled != tc.enabled {
+				t.Errorf("got enabled %v, want %v", got.Enabled, tc.enabled)
+			}
+			if got.Target != "10.0.0.5" {
+				t.Errorf("got target %q, want the translate target", got.Target)
+			}
+		})
+	}
+}
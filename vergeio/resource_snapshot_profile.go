@@ -0,0 +1,393 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// SnapshotProfileEndpoint is the api endpoint representing this resource
+const SnapshotProfileEndpoint = "api/v4/snapshot_profiles"
+
+// SnapshotProfilePeriodEndpoint is the api endpoint for the periods of a snapshot profile
+const SnapshotProfilePeriodEndpoint = "api/v4/snapshot_profile_periods"
+
+// SnapshotProfile is the data structure for a snapshot profile in vergeos
+type SnapshotProfile struct {
+	Key         int    `json:"$key,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SnapshotProfilePeriod is the data structure for a schedule and retention of a snapshot profile
+type SnapshotProfilePeriod struct {
+	Key          int    `json:"$key,omitempty"`
+	Profile      int    `json:"profile,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Frequency    string `json:"frequency,omitempty"`
+	Minute       int    `json:"minute"`
+	Hour         int    `json:"hour"`
+	DayOfWeek    string `json:"day_of_week,omitempty"`
+	DayOfMonth   int    `json:"day_of_month"`
+	Retention    int    `json:"retention,omitempty"`
+	MinSnapshots int    `json:"min_snapshots"`
+	Immutable    bool   `json:"immutable"`
+}
+
+var snapshotProfileFrequencies = []string{"hourly", "daily", "weekly", "monthly", "yearly"}
+
+var snapshotProfileDaysOfWeek = []string{"any", "sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func newSnapshotProfileFromResource(d *schema.ResourceData) *SnapshotProfile {
+	profile := &SnapshotProfile{}
+	if d.HasChange("name") {
+		profile.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		profile.Description = d.Get("description").(string)
+	}
+	return profile
+}
+
+func resourceSnapshotProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSnapshotProfileCreate,
+		ReadContext:   resourceSnapshotProfileRead,
+		UpdateContext: resourceSnapshotProfileUpdate,
+		DeleteContext: resourceSnapshotProfileDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"period": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the period, unique within the profile",
+						},
+						"frequency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(snapshotProfileFrequencies, false),
+						},
+						"minute": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 59),
+						},
+						"hour": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"day_of_week": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "any",
+							ValidateFunc: validation.StringInSlice(snapshotProfileDaysOfWeek, false),
+						},
+						"day_of_month": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 31),
+							Description:  "Day of the month for monthly and yearly periods, 0 for any",
+						},
+						"retention": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "How long snapshots taken by the period are kept, in seconds. This is a duration, not a snapshot count; see min_snapshots",
+						},
+						"min_snapshots": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of snapshots kept even once they are past retention",
+						},
+						"immutable": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Snapshots taken by the period cannot be deleted before they expire",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSnapshotProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newSnapshotProfileFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		SnapshotProfileEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	if d.HasChange("period") {
+		profile, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = syncSnapshotProfilePeriods(client, profile, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSnapshotProfileRead(ctx, d, m)
+}
+
+func resourceSnapshotProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newSnapshotProfileFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(SnapshotProfileEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	profile, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = syncSnapshotProfilePeriods(c, profile, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceSnapshotProfileRead(ctx, d, m)
+}
+
+func resourceSnapshotProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		SnapshotProfileEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "$key,name,description"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var profile SnapshotProfile
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &profile)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", profile)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving snapshot profile data")
+	}
+
+	periods, err := getSnapshotProfilePeriods(c, profile.Key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", profile.Name)
+	d.Set("description", profile.Description)
+	d.Set("period", flattenSnapshotProfilePeriods(periods))
+	return diags
+}
+
+func resourceSnapshotProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		SnapshotProfileEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getSnapshotProfilePeriods retrieves the periods of a snapshot profile
+func getSnapshotProfilePeriods(c *Client, profile int) ([]SnapshotProfilePeriod, error) {
+	request, err := c.Get(SnapshotProfilePeriodEndpoint, &Options{
+		Fields: "$key,profile,name,frequency,minute,hour,day_of_week,day_of_month,retention,min_snapshots,immutable",
+		Filter: fmt.Sprintf("profile eq %d", profile),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var periods []SnapshotProfilePeriod
+	err = json.Unmarshal(body, &periods)
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// syncSnapshotProfilePeriods makes the periods of a profile match the period blocks of d, matching them by name
+func syncSnapshotProfilePeriods(c *Client, profile int, d *schema.ResourceData) error {
+	wanted := map[string]SnapshotProfilePeriod{}
+	for _, v := range d.Get("period").(*schema.Set).List() {
+		p := v.(map[string]interface{})
+		period := SnapshotProfilePeriod{
+			Profile:      profile,
+			Name:         p["name"].(string),
+			Frequency:    p["frequency"].(string),
+			Minute:       p["minute"].(int),
+			Hour:         p["hour"].(int),
+			DayOfWeek:    p["day_of_week"].(string),
+			DayOfMonth:   p["day_of_month"].(int),
+			Retention:    p["retention"].(int),
+			MinSnapshots: p["min_snapshots"].(int),
+			Immutable:    p["immutable"].(bool),
+		}
+		if _, ok := wanted[period.Name]; ok {
+			return fmt.Errorf("snapshot profile has more than one period named %q", period.Name)
+		}
+		wanted[period.Name] = period
+	}
+
+	current, err := getSnapshotProfilePeriods(c, profile)
+	if err != nil {
+		return err
+	}
+	for _, period := range current {
+		update, ok := wanted[period.Name]
+		if !ok {
+			_, err = c.Delete(fmt.Sprintf("%s/%d", SnapshotProfilePeriodEndpoint, period.Key))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		delete(wanted, period.Name)
+		key := period.Key
+		period.Key = 0
+		if update == period {
+			continue
+		}
+		bytedata, err := json.Marshal(&update)
+		if err != nil {
+			return err
+		}
+		_, err = c.Put(fmt.Sprintf("%s/%d", SnapshotProfilePeriodEndpoint, key), bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+	}
+	for _, period := range wanted {
+		bytedata, err := json.Marshal(&period)
+		if err != nil {
+			return err
+		}
+		request, err := c.Post(SnapshotProfilePeriodEndpoint, bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return err
+		}
+		var resp VergeResponse
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf(resp.Error)
+		}
+	}
+	return nil
+}
+
+// flattenSnapshotProfilePeriods converts snapshot profile periods into period blocks
+func flattenSnapshotProfilePeriods(periods []SnapshotProfilePeriod) []map[string]interface{} {
+	flattened := []map[string]interface{}{}
+	for _, period := range periods {
+		flattened = append(flattened, map[string]interface{}{
+			"name":          period.Name,
+			"frequency":     period.Frequency,
+			"minute":        period.Minute,
+			"hour":          period.Hour,
+			"day_of_week":   period.DayOfWeek,
+			"day_of_month":  period.DayOfMonth,
+			"retention":     period.Retention,
+			"min_snapshots": period.MinSnapshots,
+			"immutable":     period.Immutable,
+		})
+	}
+	return flattened
+}
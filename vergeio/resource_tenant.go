@@ -0,0 +1,428 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// TenantEndpoint is the api endpoint representing this resource
+const TenantEndpoint = "api/v4/tenants"
+
+// TenantActionEndpoint is the api endpoint used to run actions against a tenant
+const TenantActionEndpoint = "api/v4/tenant_actions"
+
+// Tenant is the data structure for tenants in vergeos
+type Tenant struct {
+	Key                  int    `json:"$key,omitempty"`
+	Name                 string `json:"name,omitempty"`
+	Description          string `json:"description,omitempty"`
+	UIAddressVNET        int    `json:"ui_address_vnet,omitempty"`
+	UIAddress            string `json:"ui_address_ip,omitempty"`
+	AdminUser            string `json:"admin_user,omitempty"`
+	AdminPassword        string `json:"admin_user_password,omitempty"`
+	ExposeCloudSnapshots bool   `json:"expose_cloud_snapshots"`
+	Isolated             bool   `json:"isolate"`
+	VNET                 int    `json:"vnet,omitempty"`
+	Running              bool   `json:"running,omitempty"`
+	Status               string `json:"status,omitempty"`
+}
+
+// TenantAction is the data structure for actions run against a tenant
+type TenantAction struct {
+	Tenant int    `json:"tenant"`
+	Action string `json:"action"`
+}
+
+func newTenantFromResource(d *schema.ResourceData) *Tenant {
+	tenant := &Tenant{}
+	if d.HasChange("name") {
+		tenant.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		tenant.Description = d.Get("description").(string)
+	}
+	if d.HasChange("network") {
+		tenant.UIAddressVNET = d.Get("network").(int)
+	}
+	if d.HasChange("ui_address") {
+		tenant.UIAddress = d.Get("ui_address").(string)
+	}
+	// the admin user is only created along with the tenant
+	if d.IsNewResource() {
+		tenant.AdminUser = d.Get("admin_user").(string)
+		tenant.AdminPassword = d.Get("admin_password").(string)
+	}
+	tenant.ExposeCloudSnapshots = d.Get("expose_cloud_snapshots").(bool)
+	tenant.Isolated = d.Get("isolated").(bool)
+	return tenant
+}
+
+func resourceTenant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantCreate,
+		ReadContext:   resourceTenantRead,
+		UpdateContext: resourceTenantUpdate,
+		DeleteContext: resourceTenantDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"network": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Key of the network the tenant UI address is placed on",
+			},
+			"ui_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"admin_user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Administrator user created in the tenant. Only used when the tenant is created",
+			},
+			"admin_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"admin_user"},
+				Description:  "Password of the administrator user. Only used when the tenant is created, never read back",
+			},
+			"expose_cloud_snapshots": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"isolated": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"power_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "on",
+				ValidateFunc: validation.StringInSlice([]string{
+					"on",
+					"off",
+				}, false),
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Refuse to delete the tenant while set",
+			},
+			"vnet": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Key of the network created for the tenant",
+			},
+			"running": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTenantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newTenantFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		TenantEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	if d.HasChange("power_state") {
+		err = setTenantPowerState(ctx, client, d, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceTenantRead(ctx, d, m)
+}
+
+func resourceTenantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newTenantFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(TenantEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = setTenantPowerState(ctx, c, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceTenantRead(ctx, d, m)
+}
+
+func resourceTenantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		TenantEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "name,description,ui_address_vnet,ui_address_ip,expose_cloud_snapshots,isolate,vnet,status#running as running,status#status as status"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var tenant Tenant
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &tenant)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", tenant)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving tenant data")
+	}
+
+	d.Set("name", tenant.Name)
+	d.Set("description", tenant.Description)
+	d.Set("network", tenant.UIAddressVNET)
+	d.Set("ui_address", tenant.UIAddress)
+	d.Set("expose_cloud_snapshots", tenant.ExposeCloudSnapshots)
+	d.Set("isolated", tenant.Isolated)
+	d.Set("vnet", tenant.VNET)
+	d.Set("running", tenant.Running)
+	d.Set("status", tenant.Status)
+	if tenant.Running {
+		d.Set("power_state", "on")
+	} else {
+		d.Set("power_state", "off")
+	}
+	return diags
+}
+
+func resourceTenantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Tenant %s has deletion_protection set, set it to false and apply before destroying the tenant", d.Get("name").(string))
+	}
+
+	// a tenant has to be powered off before it can be deleted
+	tenant, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	running, err := getTenantRunning(client, tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if running {
+		err = runTenantAction(client, tenant, "poweroff")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = waitForTenantRunning(ctx, client, tenant, false, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	_, err = client.Delete(fmt.Sprintf("%s/%s",
+		TenantEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// setTenantPowerState powers the tenant on or off to match power_state and waits for it to get there
+func setTenantPowerState(ctx context.Context, c *Client, d *schema.ResourceData, timeout time.Duration) error {
+	tenant, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	running := d.Get("power_state").(string) == "on"
+	current, err := getTenantRunning(c, tenant)
+	if err != nil {
+		return err
+	}
+	if current == running {
+		return nil
+	}
+	action := "poweroff"
+	if running {
+		action = "poweron"
+	}
+	err = runTenantAction(c, tenant, action)
+	if err != nil {
+		return err
+	}
+	return waitForTenantRunning(ctx, c, tenant, running, timeout)
+}
+
+// runTenantAction runs action against the tenant
+func runTenantAction(c *Client, tenant int, action string) error {
+	bytedata, err := json.Marshal(&TenantAction{
+		Tenant: tenant,
+		Action: action,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.Post(TenantActionEndpoint, bytes.NewBuffer(bytedata))
+	return err
+}
+
+// getTenantRunning reports whether the tenant is powered on
+func getTenantRunning(c *Client, tenant int) (bool, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		TenantEndpoint,
+		tenant,
+	), &Options{Fields: "status#running as running"})
+	if err != nil {
+		return false, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return false, err
+	}
+	var status Tenant
+	err = json.Unmarshal(body, &status)
+	if err != nil {
+		return false, err
+	}
+	return status.Running, nil
+}
+
+// getTenantKey resolves tenant, given as a key or a name, to the key of a tenant of this system
+func getTenantKey(c *Client, tenant string) (int, error) {
+	filter := fmt.Sprintf("name eq '%s'", tenant)
+	if key, err := strconv.Atoi(tenant); err == nil {
+		filter = fmt.Sprintf("$key eq %d", key)
+	}
+	request, err := c.Get(TenantEndpoint, &Options{Fields: "$key,name", Filter: filter})
+	if err != nil {
+		return 0, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return 0, err
+	}
+	var tenants []Tenant
+	err = json.Unmarshal(body, &tenants)
+	if err != nil {
+		return 0, err
+	}
+	if len(tenants) != 1 {
+		return 0, fmt.Errorf("expected 1 tenant matching %q, found %d", tenant, len(tenants))
+	}
+	return tenants[0].Key, nil
+}
+
+// waitForTenantRunning polls the tenant until its running state matches running or timeout passes
+func waitForTenantRunning(ctx context.Context, c *Client, tenant int, running bool, timeout time.Duration) error {
+	return waitFor(ctx, timeout, fmt.Sprintf("tenant %d to reach running=%t", tenant, running), func() (bool, error) {
+		current, err := getTenantRunning(c, tenant)
+		return current == running, err
+	})
+}
+
+// waitFor polls done every few seconds until it reports true, returns an error or timeout passes
+func waitFor(ctx context.Context, timeout time.Duration, what string, done func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s", what)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func newTenantIPFromResource(d *schema.ResourceData) *NetworkAddress {
+	address := &NetworkAddress{
+		Type: "virtual",
+	}
+	if d.HasChange("vnet") {
+		address.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("tenant") {
+		address.Owner = fmt.Sprintf("tenants/%d", d.Get("tenant").(int))
+	}
+	if d.HasChange("ip") {
+		address.IP = d.Get("ip").(string)
+	}
+	if d.HasChange("description") {
+		address.Description = d.Get("description").(string)
+	}
+	return address
+}
+
+func resourceTenantIP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantIPCreate,
+		ReadContext:   resourceTenantIPRead,
+		UpdateContext: resourceTenantIPUpdate,
+		DeleteContext: resourceTenantIPDelete,
+		CustomizeDiff: resourceTenantIPCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"tenant": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"vnet": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the network the address is handed off from, typically External",
+			},
+			"ip": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceTenantIPCustomizeDiff checks that ip does not overlap an address already on the vnet
+func resourceTenantIPCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChanges("vnet", "ip") {
+		return nil
+	}
+	return checkNetworkAddressOverlap(m.(*Client), d.Get("vnet").(int), "ip", d.Get("ip").(string), d.Id())
+}
+
+func resourceTenantIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newTenantIPFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceTenantIPRead(ctx, d, m)
+}
+
+func resourceTenantIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newTenantIPFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkAddressEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceTenantIPRead(ctx, d, m)
+}
+
+func resourceTenantIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "$key,vnet,type,ip,description,owner"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var address NetworkAddress
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &address)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", address)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving tenant ip data")
+	}
+
+	d.Set("tenant", networkAddressTenant(address.Owner))
+	d.Set("vnet", address.VNET)
+	d.Set("ip", address.IP)
+	d.Set("description", address.Description)
+	return diags
+}
+
+func resourceTenantIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,205 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func newTenantNetworkBlockFromResource(d *schema.ResourceData) *NetworkAddress {
+	block := &NetworkAddress{
+		Type: "ipblock",
+	}
+	if d.HasChange("vnet") {
+		block.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("tenant") {
+		block.Owner = fmt.Sprintf("tenants/%d", d.Get("tenant").(int))
+	}
+	if d.HasChange("cidr") {
+		block.IP = d.Get("cidr").(string)
+	}
+	if d.HasChange("description") {
+		block.Description = d.Get("description").(string)
+	}
+	return block
+}
+
+func resourceTenantNetworkBlock() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantNetworkBlockCreate,
+		ReadContext:   resourceTenantNetworkBlockRead,
+		UpdateContext: resourceTenantNetworkBlockUpdate,
+		DeleteContext: resourceTenantNetworkBlockDelete,
+		CustomizeDiff: resourceTenantNetworkBlockCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"tenant": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"vnet": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the network the block is delegated from, typically External",
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDRNetwork(8, 32),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceTenantNetworkBlockCustomizeDiff checks that cidr does not overlap an address already on the vnet
+func resourceTenantNetworkBlockCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChanges("vnet", "cidr") {
+		return nil
+	}
+	return checkNetworkAddressOverlap(m.(*Client), d.Get("vnet").(int), "cidr", d.Get("cidr").(string), d.Id())
+}
+
+func resourceTenantNetworkBlockUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newTenantNetworkBlockFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+	return resourceTenantNetworkBlockRead(ctx, d, m)
+}
+
+func resourceTenantNetworkBlockCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newTenantNetworkBlockFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(NetworkAddressEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceTenantNetworkBlockRead(ctx, d, m)
+}
+
+func resourceTenantNetworkBlockRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "$key,vnet,type,ip,description,owner"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var block NetworkAddress
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &block)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", block)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving tenant network block data")
+	}
+
+	d.Set("tenant", networkAddressTenant(block.Owner))
+	d.Set("vnet", block.VNET)
+	d.Set("cidr", block.IP)
+	d.Set("description", block.Description)
+	return diags
+}
+
+func resourceTenantNetworkBlockDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		NetworkAddressEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// networkAddressTenant returns the key of the tenant owning an address, 0 when a tenant does not own it
+func networkAddressTenant(owner string) int {
+	var tenant int
+	fmt.Sscanf(owner, "tenants/%d", &tenant)
+	return tenant
+}
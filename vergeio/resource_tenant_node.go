@@ -0,0 +1,275 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// TenantNodeEndpoint is the api endpoint representing this resource
+const TenantNodeEndpoint = "api/v4/tenant_nodes"
+
+// TenantNode is the data structure for compute nodes allocated to a tenant in vergeos
+type TenantNode struct {
+	Tenant        int     `json:"tenant,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	CPUCores      int     `json:"cpu_cores,omitempty"`
+	RAM           int     `json:"ram,omitempty"`
+	Cluster       int     `json:"cluster,omitempty"`
+	PreferredNode int     `json:"preferred_node,omitempty"`
+	Enabled       bool    `json:"enabled"`
+	Status        string  `json:"status,omitempty"`
+	CPUUsage      float64 `json:"cpu_usage,omitempty"`
+	RAMUsed       int     `json:"ram_used,omitempty"`
+}
+
+func newTenantNodeFromResource(d *schema.ResourceData) *TenantNode {
+	node := &TenantNode{}
+	if d.HasChange("tenant") {
+		node.Tenant = d.Get("tenant").(int)
+	}
+	if d.HasChange("description") {
+		node.Description = d.Get("description").(string)
+	}
+	if d.HasChange("cpu_cores") {
+		node.CPUCores = d.Get("cpu_cores").(int)
+	}
+	if d.HasChange("ram") {
+		node.RAM = d.Get("ram").(int)
+	}
+	if d.HasChange("cluster") {
+		node.Cluster = d.Get("cluster").(int)
+	}
+	if d.HasChange("preferred_node") {
+		node.PreferredNode = d.Get("preferred_node").(int)
+	}
+	node.Enabled = d.Get("enabled").(bool)
+	return node
+}
+
+func resourceTenantNode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantNodeCreate,
+		ReadContext:   resourceTenantNodeRead,
+		UpdateContext: resourceTenantNodeUpdate,
+		DeleteContext: resourceTenantNodeDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cpu_cores": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ram": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1024),
+				Description:  "RAM in Megabytes (MB)",
+			},
+			"cluster": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"preferred_node": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cpu_usage": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Current CPU usage in percent",
+			},
+			"ram_used": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current RAM usage in Megabytes (MB)",
+			},
+		},
+	}
+}
+
+func resourceTenantNodeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newTenantNodeFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		TenantNodeEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = waitForTenantNode(ctx, client, d, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceTenantNodeRead(ctx, d, m)
+}
+
+func resourceTenantNodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newTenantNodeFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(TenantNodeEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = waitForTenantNode(ctx, c, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceTenantNodeRead(ctx, d, m)
+}
+
+func resourceTenantNodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	node, err := getTenantNode(c, d.Id())
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	log.Printf("[DEBUG] params %#v", node)
+
+	d.Set("tenant", node.Tenant)
+	d.Set("description", node.Description)
+	d.Set("cpu_cores", node.CPUCores)
+	d.Set("ram", node.RAM)
+	d.Set("cluster", node.Cluster)
+	d.Set("preferred_node", node.PreferredNode)
+	d.Set("enabled", node.Enabled)
+	d.Set("name", node.Name)
+	d.Set("status", node.Status)
+	d.Set("cpu_usage", node.CPUUsage)
+	d.Set("ram_used", node.RAMUsed)
+	return diags
+}
+
+func resourceTenantNodeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		TenantNodeEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// getTenantNode retrieves a single tenant node by its key
+func getTenantNode(c *Client, id string) (*TenantNode, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		TenantNodeEndpoint,
+		url.PathEscape(id),
+	), &Options{Fields: "tenant,name,description,cpu_cores,ram,cluster,preferred_node,enabled,machine#status#status as status,machine#stats#total_cpu as cpu_usage,machine#stats#ram_used as ram_used"})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var node TenantNode
+	err = json.Unmarshal(body, &node)
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// waitForTenantNode waits for an enabled node of a running tenant to come up.
+// Nodes of a powered off tenant stay stopped, so there is nothing to wait for.
+func waitForTenantNode(ctx context.Context, c *Client, d *schema.ResourceData, timeout time.Duration) error {
+	if !d.Get("enabled").(bool) {
+		return nil
+	}
+	running, err := getTenantRunning(c, d.Get("tenant").(int))
+	if err != nil || !running {
+		return err
+	}
+	return waitFor(ctx, timeout, fmt.Sprintf("tenant node %s to start", d.Id()), func() (bool, error) {
+		node, err := getTenantNode(c, d.Id())
+		if err != nil {
+			return false, err
+		}
+		return node.Status == "running", nil
+	})
+}
@@ -0,0 +1,208 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// TenantStorageEndpoint is the api endpoint representing this resource
+const TenantStorageEndpoint = "api/v4/tenant_storage"
+
+// TenantStorage is the data structure for storage allocated to a tenant in vergeos
+type TenantStorage struct {
+	Tenant      int     `json:"tenant,omitempty"`
+	Tier        int     `json:"tier,omitempty"`
+	Provisioned int64   `json:"provisioned,omitempty"`
+	Used        int64   `json:"used,omitempty"`
+	UsedPercent float64 `json:"used_pct,omitempty"`
+}
+
+const gigabyte = int64(1024 * 1024 * 1024)
+
+func newTenantStorageFromResource(d *schema.ResourceData) *TenantStorage {
+	storage := &TenantStorage{}
+	if d.HasChange("tenant") {
+		storage.Tenant = d.Get("tenant").(int)
+	}
+	if d.HasChange("tier") {
+		storage.Tier = d.Get("tier").(int)
+	}
+	if d.HasChange("provisioned") {
+		storage.Provisioned = int64(d.Get("provisioned").(int)) * gigabyte // Convert GB to bytes
+	}
+	return storage
+}
+
+func resourceTenantStorage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantStorageCreate,
+		ReadContext:   resourceTenantStorageRead,
+		UpdateContext: resourceTenantStorageUpdate,
+		DeleteContext: resourceTenantStorageDelete,
+		CustomizeDiff: resourceTenantStorageCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"tenant": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"tier": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 5),
+			},
+			"provisioned": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Storage provisioned to the tenant in Gigabytes (GB). Can only grow",
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Storage used by the tenant in Gigabytes (GB)",
+			},
+			"used_percent": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTenantStorageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newTenantStorageFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	log.Printf("[DEBUG] resource data %s", string(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		TenantStorageEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	return resourceTenantStorageRead(ctx, d, m)
+}
+
+func resourceTenantStorageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newTenantStorageFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(TenantStorageEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	return resourceTenantStorageRead(ctx, d, m)
+}
+
+func resourceTenantStorageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	storage, err := getTenantStorage(c, d.Id())
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	log.Printf("[DEBUG] params %#v", storage)
+
+	d.Set("tenant", storage.Tenant)
+	d.Set("tier", storage.Tier)
+	d.Set("provisioned", int(storage.Provisioned/gigabyte)) // Convert bytes to GB
+	d.Set("used", int(storage.Used/gigabyte))
+	d.Set("used_percent", storage.UsedPercent)
+	return diags
+}
+
+func resourceTenantStorageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		TenantStorageEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceTenantStorageCustomizeDiff refuses to shrink a tenant storage allocation
+func resourceTenantStorageCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("provisioned") {
+		return nil
+	}
+	old, new := d.GetChange("provisioned")
+	if new.(int) < old.(int) {
+		return fmt.Errorf("provisioned can only grow, it cannot be reduced from %d GB to %d GB", old.(int), new.(int))
+	}
+	return nil
+}
+
+// getTenantStorage retrieves a single tenant storage allocation by its key
+func getTenantStorage(c *Client, id string) (*TenantStorage, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		TenantStorageEndpoint,
+		url.PathEscape(id),
+	), &Options{Fields: "tenant,tier,provisioned,used,used_pct"})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var storage TenantStorage
+	err = json.Unmarshal(body, &storage)
+	if err != nil {
+		return nil, err
+	}
+	return &storage, nil
+}
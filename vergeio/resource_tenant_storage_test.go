@@ -0,0 +1,43 @@
+package vergeio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestTenantStorageCustomizeDiff(t *testing.T) {
+	cases := []struct {
+		name        string
+		current     string
+		provisioned int
+		wantErr     bool
+	}{
+		{name: "create", provisioned: 100},
+		{name: "unchanged", current: "100", provisioned: 100},
+		{name: "grow", current: "100", provisioned: 200},
+		{name: "shrink", current: "200", provisioned: 100, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.current != "" {
+				state = &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+					"tenant":      "1",
+					"tier":        "1",
+					"provisioned": tc.current,
+				}}
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"tenant":      1,
+				"tier":        1,
+				"provisioned": tc.provisioned,
+			})
+			_, err := resourceTenantStorage().Diff(context.Background(), state, config, nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
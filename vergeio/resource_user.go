@@ -8,6 +8,9 @@ import (
 	"io/ioutil"
 	"log"
 	"net/url"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,6 +20,17 @@ import (
 // UserEndpoint is the api endpoint representing this resource
 const UserEndpoint = "api/v4/users"
 
+// UserSSHKeyEndpoint is the api endpoint representing the ssh public keys of a user
+const UserSSHKeyEndpoint = "api/v4/user_ssh_keys"
+
+// UserSSHKey is the data structure for ssh public keys of a user in vergeos
+type UserSSHKey struct {
+	Key       int    `json:"$key,omitempty"`
+	User      int    `json:"user,omitempty"`
+	Name      string `json:"name,omitempty"`
+	PublicKey string `json:"key,omitempty"`
+}
+
 // User is the data structure for virtual machines in vergeos
 type User struct {
 	AuthSource     int    `json:"auth_source,omitempty"`
@@ -28,6 +42,13 @@ type User struct {
 	Type           string `json:"type,omitempty"`
 	Password       string `json:"password,omitempty"`
 	ChangePassword bool   `json:"change_password"`
+	Identity       int    `json:"identity,omitempty"`
+	PasswordSet    int64  `json:"password_set,omitempty"`
+	Locked         bool   `json:"locked,omitempty"`
+	TwoFactor      *bool  `json:"two_factor_authentication,omitempty"`
+	TwoFactorType  string `json:"two_factor_type,omitempty"`
+	TwoFactorSetup *bool  `json:"two_factor_setup_next_login,omitempty"`
+	TwoFactorReady bool   `json:"two_factor_enabled,omitempty"`
 }
 
 func newUserFromResource(d *schema.ResourceData) *User {
@@ -56,9 +77,23 @@ func newUserFromResource(d *schema.ResourceData) *User {
 	if d.HasChange("password") {
 		user.Password = d.Get("password").(string)
 	}
+	if d.HasChange("password_wo") && d.Get("password_wo").(string) != "" {
+		user.Password = d.Get("password_wo").(string)
+	}
 	if d.HasChange("change_password") {
 		user.ChangePassword = d.Get("change_password").(bool)
 	}
+	if d.HasChange("require_twofactor") {
+		twoFactor := d.Get("require_twofactor").(bool)
+		user.TwoFactor = &twoFactor
+	}
+	if d.HasChange("twofactor_type") {
+		user.TwoFactorType = d.Get("twofactor_type").(string)
+	}
+	if d.HasChange("twofactor_setup_next_login") {
+		twoFactorSetup := d.Get("twofactor_setup_next_login").(bool)
+		user.TwoFactorSetup = &twoFactorSetup
+	}
 	return user
 }
 
@@ -111,15 +146,86 @@ func resourceUser() *schema.Resource {
 				Computed: true,
 			},
 			"password": {
-				Type:     schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password_wo"},
+				Deprecated:    "password is stored in state, use password_wo and password_wo_version instead",
+			},
+			"password_wo": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressUnversionedPassword,
+				ConflictsWith:    []string{"password"},
+				Description:      "Password sent on create and whenever password_wo_version changes. It is never stored in state",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Change this to send password_wo again",
+			},
+			"change_password": {
+				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
-			"change_password": {
+			"identity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"require_twofactor": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Computed: true,
 			},
+			"twofactor_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"email",
+					"authenticator",
+				}, false),
+			},
+			"twofactor_setup_next_login": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Make the user set up two-factor authentication on their next login",
+			},
+			"ssh_key": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(ssh-|ecdsa-|sk-)\S+ \S+`), "must be an OpenSSH public key"),
+						},
+					},
+				},
+			},
+			"twofactor_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has completed two-factor authentication setup",
+			},
+			"password_last_changed": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -145,6 +251,17 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	if req.StatusCode != 200 {
 		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
 	}
+
+	if d.HasChange("ssh_key") {
+		user, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = syncUserSSHKeys(client, user, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceUserRead(ctx, d, m)
 }
 
@@ -174,6 +291,17 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf(resp.Error)
 	}
 	d.SetId(string(resp.Key))
+
+	if d.Get("ssh_key").(*schema.Set).Len() > 0 {
+		user, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = syncUserSSHKeys(c, user, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceUserRead(ctx, d, m)
 }
 
@@ -185,7 +313,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	request, err := c.Get(fmt.Sprintf("%s/%s",
 		UserEndpoint,
 		url.PathEscape(d.Id()),
-	), nil)
+	), &Options{Fields: "auth_source,name,remote_name,enabled,displayname,email,type,change_password,identity,password_set,locked,two_factor_authentication,two_factor_type,two_factor_setup_next_login,two_factor_enabled"})
 	if request != nil && request.StatusCode == 404 {
 		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
 		d.SetId("")
@@ -223,8 +351,38 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	d.Set("displayname", user.DisplayName)
 	d.Set("email", user.Email)
 	d.Set("type", user.Type)
-	d.Set("password", user.Password)
 	d.Set("change_password", user.ChangePassword)
+	d.Set("identity", user.Identity)
+	d.Set("locked", user.Locked)
+	if user.TwoFactor != nil {
+		d.Set("require_twofactor", *user.TwoFactor)
+	}
+	d.Set("twofactor_type", user.TwoFactorType)
+	if user.TwoFactorSetup != nil {
+		d.Set("twofactor_setup_next_login", *user.TwoFactorSetup)
+	}
+	d.Set("twofactor_enabled", user.TwoFactorReady)
+
+	userKey, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sshKeys, err := getUserSSHKeys(c, userKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("ssh_key", flattenUserSSHKeys(sshKeys))
+	if user.PasswordSet > 0 {
+		d.Set("password_last_changed", time.Unix(user.PasswordSet, 0).UTC().Format(time.RFC3339))
+	} else {
+		d.Set("password_last_changed", "")
+	}
+	// the deprecated password is not kept in state once password_wo is in use
+	if d.Get("password_wo").(string) != "" || d.Get("password_wo_version").(int) != 0 {
+		d.Set("password", "")
+	}
+	// password_wo is only needed while it is being sent, drop it from state
+	d.Set("password_wo", "")
 
 	return diags
 }
@@ -241,3 +399,97 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface
 	}
 	return diags
 }
+
+// suppressUnversionedPassword hides password_wo from the diff once the user
+// exists, unless password_wo_version changes. The value is blanked in state
+// after it is sent, so without this every plan would try to send it again.
+func suppressUnversionedPassword(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && !d.HasChange("password_wo_version")
+}
+
+// getUserSSHKeys retrieves all ssh public keys of user
+func getUserSSHKeys(c *Client, user int) ([]UserSSHKey, error) {
+	request, err := c.Get(UserSSHKeyEndpoint, &Options{
+		Fields: "$key,user,name,key",
+		Filter: fmt.Sprintf("user eq %d", user),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var keys []UserSSHKey
+	err = json.Unmarshal(body, &keys)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// syncUserSSHKeys makes the ssh public keys of user match the ssh_key blocks
+// of d exactly, removing keys that are not listed
+func syncUserSSHKeys(c *Client, user int, d *schema.ResourceData) error {
+	wanted := map[string]UserSSHKey{}
+	for _, v := range d.Get("ssh_key").(*schema.Set).List() {
+		sshKey := v.(map[string]interface{})
+		key := UserSSHKey{
+			User:      user,
+			Name:      sshKey["name"].(string),
+			PublicKey: sshKey["key"].(string),
+		}
+		wanted[key.Name+"\n"+key.PublicKey] = key
+	}
+
+	current, err := getUserSSHKeys(c, user)
+	if err != nil {
+		return err
+	}
+	for _, key := range current {
+		id := key.Name + "\n" + key.PublicKey
+		if _, ok := wanted[id]; ok {
+			delete(wanted, id)
+			continue
+		}
+		_, err = c.Delete(fmt.Sprintf("%s/%d", UserSSHKeyEndpoint, key.Key))
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range wanted {
+		bytedata, err := json.Marshal(&key)
+		if err != nil {
+			return err
+		}
+		request, err := c.Post(UserSSHKeyEndpoint, bytes.NewBuffer(bytedata))
+		if err != nil {
+			return err
+		}
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return err
+		}
+		var resp VergeResponse
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf(resp.Error)
+		}
+	}
+	return nil
+}
+
+// flattenUserSSHKeys converts ssh public keys into ssh_key blocks
+func flattenUserSSHKeys(keys []UserSSHKey) []map[string]interface{} {
+	sshKeys := []map[string]interface{}{}
+	for _, key := range keys {
+		sshKeys = append(sshKeys, map[string]interface{}{
+			"name": key.Name,
+			"key":  key.PublicKey,
+		})
+	}
+	return sshKeys
+}
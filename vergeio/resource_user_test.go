@@ -0,0 +1,57 @@
+package vergeio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSuppressUnversionedPassword(t *testing.T) {
+	cases := []struct {
+		name   string
+		state  map[string]string
+		config map[string]interface{}
+		want   bool
+	}{
+		{
+			name:   "sent on create",
+			config: map[string]interface{}{"name": "test", "password_wo": "secret"},
+			want:   true,
+		},
+		{
+			name:   "ignored without a version",
+			state:  map[string]string{"name": "test", "password_wo": ""},
+			config: map[string]interface{}{"name": "test", "password_wo": "secret"},
+			want:   false,
+		},
+		{
+			name:   "ignored while the version is unchanged",
+			state:  map[string]string{"name": "test", "password_wo": "", "password_wo_version": "1"},
+			config: map[string]interface{}{"name": "test", "password_wo": "rotated", "password_wo_version": 1},
+			want:   false,
+		},
+		{
+			name:   "sent when the version changes",
+			state:  map[string]string{"name": "test", "password_wo": "", "password_wo_version": "1"},
+			config: map[string]interface{}{"name": "test", "password_wo": "rotated", "password_wo_version": 2},
+			want:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.state != nil {
+				state = &terraform.InstanceState{ID: "1", Attributes: tc.state}
+			}
+			diff, err := resourceUser().Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := diff != nil && diff.Attributes["password_wo"] != nil
+			if got != tc.want {
+				t.Errorf("password_wo in diff = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ import (
 // VMEndpoint is the api endpoint representing this resource
 const VMEndpoint = "api/v4/vms"
 
+// VMActionEndpoint is the api endpoint used to run actions against a virtual machine
+const VMActionEndpoint = "api/v4/vm_actions"
+
 // VM is the data structure for virtual machines in vergeos
 type VM struct {
 	Machine            int    `json:"machine,omitempty"`
@@ -50,6 +53,58 @@ type VM struct {
 	//CloudInitDataSource string `json:"os_description,omitempty"`
 }
 
+// VMAction is the data structure for actions run against a virtual machine
+type VMAction struct {
+	VM     int                    `json:"vm"`
+	Action string                 `json:"action"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// VMState is the power and hotplug state of the virtual machine owning a machine
+type VMState struct {
+	Key          int  `json:"$key"`
+	AllowHotplug bool `json:"allow_hotplug"`
+	Running      bool `json:"running"`
+}
+
+// getVMState retrieves the state of the virtual machine owning machine
+func getVMState(c *Client, machine int) (*VMState, error) {
+	request, err := c.Get(VMEndpoint, &Options{
+		Fields: "$key,allow_hotplug,machine#status#running as running",
+		Filter: fmt.Sprintf("machine eq %d", machine),
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var states []VMState
+	err = json.Unmarshal(body, &states)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, fmt.Errorf("no virtual machine found for machine %d", machine)
+	}
+	return &states[0], nil
+}
+
+// runVMAction runs action against the virtual machine vm
+func runVMAction(c *Client, vm int, action string, params map[string]interface{}) error {
+	bytedata, err := json.Marshal(&VMAction{
+		VM:     vm,
+		Action: action,
+		Params: params,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.Post(VMActionEndpoint, bytes.NewBuffer(bytedata))
+	return err
+}
+
 func newVMFromResource(d *schema.ResourceData) *VM {
 	vm := &VM{
 		Name:               d.Get("name").(string),
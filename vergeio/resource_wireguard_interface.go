@@ -0,0 +1,293 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// WireguardInterfaceEndpoint is the api endpoint representing this resource
+const WireguardInterfaceEndpoint = "api/v4/vnet_wireguards"
+
+// WireguardInterface is the data structure for wireguard interfaces on a vnet in vergeos
+type WireguardInterface struct {
+	VNET        int    `json:"vnet,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	IPaddress   string `json:"ip,omitempty"`
+	ListenPort  int    `json:"listenport,omitempty"`
+	MTU         int    `json:"mtu,omitempty"`
+	PrivateKey  string `json:"private_key,omitempty"`
+	PublicKey   string `json:"public_key,omitempty"`
+	EndpointIP  string `json:"endpoint_ip,omitempty"`
+}
+
+func newWireguardInterfaceFromResource(d *schema.ResourceData) *WireguardInterface {
+	wireguard := &WireguardInterface{}
+	if d.HasChange("vnet") {
+		wireguard.VNET = d.Get("vnet").(int)
+	}
+	if d.HasChange("name") {
+		wireguard.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		wireguard.Description = d.Get("description").(string)
+	}
+	wireguard.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("ipaddress") {
+		wireguard.IPaddress = d.Get("ipaddress").(string)
+	}
+	if d.HasChange("listen_port") {
+		wireguard.ListenPort = d.Get("listen_port").(int)
+	}
+	if d.HasChange("mtu") {
+		wireguard.MTU = d.Get("mtu").(int)
+	}
+	if d.HasChange("private_key_wo") && d.Get("private_key_wo").(string) != "" {
+		wireguard.PrivateKey = d.Get("private_key_wo").(string)
+	}
+	if d.HasChange("endpoint_ip") {
+		wireguard.EndpointIP = d.Get("endpoint_ip").(string)
+	}
+	return wireguard
+}
+
+func resourceWireguardInterface() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWireguardInterfaceCreate,
+		ReadContext:   resourceWireguardInterfaceRead,
+		UpdateContext: resourceWireguardInterfaceUpdate,
+		DeleteContext: resourceWireguardInterfaceDelete,
+		Schema: map[string]*schema.Schema{
+			"vnet": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ipaddress": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "Tunnel address of the interface in CIDR notation",
+			},
+			"listen_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      51820,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"mtu": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"private_key_wo": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressUnversionedPrivateKey,
+				Description:      "Private key sent on create and whenever private_key_wo_version changes, generated by VergeOS when not set. It is never stored in state",
+			},
+			"private_key_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"private_key_wo"},
+				Description:  "Change this to send private_key_wo again",
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"endpoint_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "Public address peers connect to",
+			},
+		},
+	}
+}
+
+func resourceWireguardInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newWireguardInterfaceFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		WireguardInterfaceEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceWireguardInterfaceRead(ctx, d, m)
+}
+
+func resourceWireguardInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newWireguardInterfaceFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(WireguardInterfaceEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = runNetworkAction(c, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceWireguardInterfaceRead(ctx, d, m)
+}
+
+func resourceWireguardInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		WireguardInterfaceEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "vnet,name,description,enabled,ip,listenport,mtu,public_key,endpoint_ip"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var wireguard WireguardInterface
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &wireguard)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving wireguard interface data")
+	}
+
+	d.Set("vnet", wireguard.VNET)
+	d.Set("name", wireguard.Name)
+	d.Set("description", wireguard.Description)
+	d.Set("enabled", wireguard.Enabled)
+	d.Set("ipaddress", wireguard.IPaddress)
+	d.Set("listen_port", wireguard.ListenPort)
+	d.Set("mtu", wireguard.MTU)
+	d.Set("public_key", wireguard.PublicKey)
+	d.Set("endpoint_ip", wireguard.EndpointIP)
+	// private_key_wo is only needed while it is being sent, drop it from state
+	d.Set("private_key_wo", "")
+	return diags
+}
+
+func resourceWireguardInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		WireguardInterfaceEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = runNetworkAction(client, d.Get("vnet").(int), "apply")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// suppressUnversionedPrivateKey hides private_key_wo from the diff once the
+// interface exists, unless private_key_wo_version changes. The value is blanked
+// in state after it is sent, so without this every plan would send it again.
+func suppressUnversionedPrivateKey(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && !d.HasChange("private_key_wo_version")
+}
+
+// getWireguardInterface retrieves a single wireguard interface by its key
+func getWireguardInterface(c *Client, wireguard int) (*WireguardInterface, error) {
+	request, err := c.Get(fmt.Sprintf("%s/%d",
+		WireguardInterfaceEndpoint,
+		wireguard,
+	), &Options{Fields: "vnet,name,ip,listenport,public_key,endpoint_ip"})
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	var wireguardInterface WireguardInterface
+	err = json.Unmarshal(body, &wireguardInterface)
+	if err != nil {
+		return nil, err
+	}
+	return &wireguardInterface, nil
+}
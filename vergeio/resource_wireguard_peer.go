@@ -0,0 +1,360 @@
+package vergeio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// WireguardPeerEndpoint is the api endpoint representing this resource
+const WireguardPeerEndpoint = "api/v4/vnet_wireguard_peers"
+
+// WireguardPeer is the data structure for peers of a wireguard interface in vergeos
+type WireguardPeer struct {
+	Wireguard    int    `json:"wireguard,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Enabled      bool   `json:"enabled"`
+	PeerIP       string `json:"peer_ip,omitempty"`
+	PublicKey    string `json:"public_key,omitempty"`
+	PresharedKey string `json:"preshared_key,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	AllowedIPs   string `json:"allowed_ips,omitempty"`
+	Keepalive    int    `json:"keepalive,omitempty"`
+}
+
+func newWireguardPeerFromResource(d *schema.ResourceData) *WireguardPeer {
+	peer := &WireguardPeer{}
+	if d.HasChange("wireguard") {
+		peer.Wireguard = d.Get("wireguard").(int)
+	}
+	if d.HasChange("name") {
+		peer.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		peer.Description = d.Get("description").(string)
+	}
+	peer.Enabled = d.Get("enabled").(bool)
+	if d.HasChange("peer_ip") {
+		peer.PeerIP = d.Get("peer_ip").(string)
+	}
+	if d.HasChange("public_key") {
+		peer.PublicKey = d.Get("public_key").(string)
+	}
+	if d.HasChange("preshared_key") {
+		peer.PresharedKey = d.Get("preshared_key").(string)
+	}
+	if d.HasChange("endpoint") {
+		peer.Endpoint = d.Get("endpoint").(string)
+	}
+	if d.HasChange("port") {
+		peer.Port = d.Get("port").(int)
+	}
+	if d.HasChange("allowed_ips") {
+		var allowedIPs []string
+		for _, ip := range d.Get("allowed_ips").([]interface{}) {
+			allowedIPs = append(allowedIPs, ip.(string))
+		}
+		peer.AllowedIPs = strings.Join(allowedIPs, ",")
+	}
+	if d.HasChange("keepalive") {
+		peer.Keepalive = d.Get("keepalive").(int)
+	}
+	return peer
+}
+
+func resourceWireguardPeer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWireguardPeerCreate,
+		ReadContext:   resourceWireguardPeerRead,
+		UpdateContext: resourceWireguardPeerUpdate,
+		DeleteContext: resourceWireguardPeerDelete,
+		Schema: map[string]*schema.Schema{
+			"wireguard": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"peer_ip": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "Tunnel address of the peer",
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"preshared_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Address or hostname the peer is reached at",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"allowed_ips": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"keepalive": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"peer_config": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "WireGuard configuration for the remote peer, its private key left as a placeholder",
+			},
+		},
+	}
+}
+
+func resourceWireguardPeerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	client := m.(*Client)
+	resource := newWireguardPeerFromResource(d)
+	bytedata, err := json.Marshal(resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	req, err := client.Put(fmt.Sprintf("%s/%s",
+		WireguardPeerEndpoint,
+		d.Id(),
+	), bytes.NewBuffer(bytedata))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if req.StatusCode != 200 {
+		return diag.Errorf(fmt.Sprintf("Error updating resource: %d", req.StatusCode))
+	}
+
+	err = applyWireguardInterface(client, d.Get("wireguard").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceWireguardPeerRead(ctx, d, m)
+}
+
+func resourceWireguardPeerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	resource := newWireguardPeerFromResource(d)
+	bytedata, err := json.Marshal(&resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	request, err := c.Post(WireguardPeerEndpoint, bytes.NewBuffer(bytedata))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body, readerr := ioutil.ReadAll(request.Body)
+	if readerr != nil {
+		return diag.FromErr(readerr)
+	}
+
+	var resp VergeResponse
+	decodeerr := json.Unmarshal(body, &resp)
+	if decodeerr != nil {
+		return diag.FromErr(decodeerr)
+	}
+	if resp.Error != "" {
+		return diag.Errorf(resp.Error)
+	}
+	d.SetId(string(resp.Key))
+
+	err = applyWireguardInterface(c, d.Get("wireguard").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceWireguardPeerRead(ctx, d, m)
+}
+
+func resourceWireguardPeerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var diags diag.Diagnostics
+
+	request, err := c.Get(fmt.Sprintf("%s/%s",
+		WireguardPeerEndpoint,
+		url.PathEscape(d.Id()),
+	), &Options{Fields: "wireguard,name,description,enabled,peer_ip,public_key,endpoint,port,allowed_ips,keepalive"})
+	if e, ok := err.(Error); ok && e.StatusCode == 404 {
+		log.Printf("ID Not Found: %s", url.PathEscape(d.Id()))
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("ID: %s", url.PathEscape(d.Id()))
+	var peer WireguardPeer
+	if request != nil {
+		if request.StatusCode == 200 {
+
+			body, readerr := ioutil.ReadAll(request.Body)
+			if readerr != nil {
+				return diag.FromErr(readerr)
+			}
+
+			decodeerr := json.Unmarshal(body, &peer)
+			if decodeerr != nil {
+				return diag.FromErr(decodeerr)
+			}
+			log.Printf("[DEBUG] params %#v", peer)
+
+		}
+	} else {
+		return diag.Errorf("Error retrieving wireguard peer data")
+	}
+
+	allowedIPs := []string{}
+	for _, ip := range strings.Split(peer.AllowedIPs, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			allowedIPs = append(allowedIPs, ip)
+		}
+	}
+
+	d.Set("wireguard", peer.Wireguard)
+	d.Set("name", peer.Name)
+	d.Set("description", peer.Description)
+	d.Set("enabled", peer.Enabled)
+	d.Set("peer_ip", peer.PeerIP)
+	d.Set("public_key", peer.PublicKey)
+	d.Set("endpoint", peer.Endpoint)
+	d.Set("port", peer.Port)
+	d.Set("allowed_ips", allowedIPs)
+	d.Set("keepalive", peer.Keepalive)
+
+	wireguard, err := getWireguardInterface(c, peer.Wireguard)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	network, err := getNetwork(c, wireguard.VNET)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vnetSubnet := networkSubnet(network.CIDR, network.IPaddress)
+	d.Set("peer_config", wireguardPeerConfig(wireguard, &peer, d.Get("preshared_key").(string), vnetSubnet))
+	return diags
+}
+
+func resourceWireguardPeerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*Client)
+	_, err := client.Delete(fmt.Sprintf("%s/%s",
+		WireguardPeerEndpoint,
+		d.Id(),
+	))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyWireguardInterface(client, d.Get("wireguard").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// applyWireguardInterface applies the pending configuration of the vnet owning wireguard
+func applyWireguardInterface(c *Client, wireguard int) error {
+	wireguardInterface, err := getWireguardInterface(c, wireguard)
+	if err != nil {
+		return err
+	}
+	return runNetworkAction(c, wireguardInterface.VNET, "apply")
+}
+
+// wireguardPeerConfig renders the configuration the remote peer needs to connect to wireguard.
+// Traffic for the tunnel and for vnetSubnet, the network behind wireguard, is routed through it.
+func wireguardPeerConfig(wireguard *WireguardInterface, peer *WireguardPeer, presharedKey string, vnetSubnet *net.IPNet) string {
+	tunnel := networkSubnet(wireguard.IPaddress, "")
+
+	var config strings.Builder
+	fmt.Fprintf(&config, "[Interface]\n")
+	fmt.Fprintf(&config, "PrivateKey = <peer private key>\n")
+	fmt.Fprintf(&config, "Address = %s\n", wireguardPeerAddress(peer.PeerIP, tunnel))
+	fmt.Fprintf(&config, "\n[Peer]\n")
+	fmt.Fprintf(&config, "PublicKey = %s\n", wireguard.PublicKey)
+	if presharedKey != "" {
+		fmt.Fprintf(&config, "PresharedKey = %s\n", presharedKey)
+	}
+	if wireguard.EndpointIP != "" {
+		fmt.Fprintf(&config, "Endpoint = %s\n", net.JoinHostPort(wireguard.EndpointIP, strconv.Itoa(wireguard.ListenPort)))
+	}
+	var allowedIPs []string
+	for _, subnet := range []*net.IPNet{tunnel, vnetSubnet} {
+		if subnet != nil {
+			allowedIPs = append(allowedIPs, subnet.String())
+		}
+	}
+	if len(allowedIPs) > 0 {
+		fmt.Fprintf(&config, "AllowedIPs = %s\n", strings.Join(allowedIPs, ", "))
+	}
+	if peer.Keepalive > 0 {
+		fmt.Fprintf(&config, "PersistentKeepalive = %d\n", peer.Keepalive)
+	}
+	return config.String()
+}
+
+// wireguardPeerAddress gives peerIP the prefix length of the tunnel, or a host prefix when the tunnel is unknown
+func wireguardPeerAddress(peerIP string, tunnel *net.IPNet) string {
+	ip := net.ParseIP(peerIP)
+	if ip == nil {
+		return peerIP
+	}
+	bits := 32
+	if ip.To4() == nil {
+		bits = 128
+	}
+	if tunnel != nil && tunnel.Contains(ip) {
+		ones, _ := tunnel.Mask.Size()
+		return fmt.Sprintf("%s/%d", peerIP, ones)
+	}
+	return fmt.Sprintf("%s/%d", peerIP, bits)
+}
@@ -0,0 +1,84 @@
+package vergeio
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestWireguardPeerConfig(t *testing.T) {
+	_, vnetSubnet, _ := net.ParseCIDR("192.168.10.0/24")
+	cases := []struct {
+		name         string
+		wireguard    WireguardInterface
+		peer         WireguardPeer
+		presharedKey string
+		vnetSubnet   *net.IPNet
+		want         []string
+		notWant      []string
+	}{
+		{
+			name:       "site to site",
+			wireguard:  WireguardInterface{IPaddress: "10.99.0.1/24", PublicKey: "serverkey", EndpointIP: "203.0.113.10", ListenPort: 51820},
+			peer:       WireguardPeer{PeerIP: "10.99.0.2", Keepalive: 25},
+			vnetSubnet: vnetSubnet,
+			want: []string{
+				"Address = 10.99.0.2/24\n",
+				"PublicKey = serverkey\n",
+				"Endpoint = 203.0.113.10:51820\n",
+				"AllowedIPs = 10.99.0.0/24, 192.168.10.0/24\n",
+				"PersistentKeepalive = 25\n",
+			},
+			notWant: []string{"PresharedKey"},
+		},
+		{
+			name:         "ipv6 endpoint is bracketed",
+			wireguard:    WireguardInterface{IPaddress: "fd00:99::1/64", EndpointIP: "2001:db8::10", ListenPort: 51821},
+			peer:         WireguardPeer{PeerIP: "fd00:99::2"},
+			presharedKey: "psk",
+			want: []string{
+				"Address = fd00:99::2/64\n",
+				"Endpoint = [2001:db8::10]:51821\n",
+				"AllowedIPs = fd00:99::/64\n",
+				"PresharedKey = psk\n",
+			},
+			notWant: []string{"PersistentKeepalive"},
+		},
+		{
+			name:       "peer outside the tunnel gets a host prefix",
+			wireguard:  WireguardInterface{IPaddress: "10.99.0.1/24"},
+			peer:       WireguardPeer{PeerIP: "10.98.0.2"},
+			vnetSubnet: vnetSubnet,
+			want: []string{
+				"Address = 10.98.0.2/32\n",
+				"AllowedIPs = 10.99.0.0/24, 192.168.10.0/24\n",
+			},
+			notWant: []string{"Endpoint"},
+		},
+		{
+			name:       "unknown tunnel still routes the vnet",
+			wireguard:  WireguardInterface{},
+			peer:       WireguardPeer{PeerIP: "fd00:99::2"},
+			vnetSubnet: vnetSubnet,
+			want: []string{
+				"Address = fd00:99::2/128\n",
+				"AllowedIPs = 192.168.10.0/24\n",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := wireguardPeerConfig(&tc.wireguard, &tc.peer, tc.presharedKey, tc.vnetSubnet)
+			for _, want := range tc.want {
+				if !strings.Contains(config, want) {
+					t.Errorf("config is missing %q:\n%s", want, config)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(config, notWant) {
+					t.Errorf("config unexpectedly contains %q:\n%s", notWant, config)
+				}
+			}
+		})
+	}
+}